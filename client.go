@@ -12,6 +12,7 @@ import (
 	balancing "github.com/adwski/ydb-go-query/internal/transport/balancing/v4"
 	"github.com/adwski/ydb-go-query/internal/transport/dispatcher"
 	qq "github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/scripts"
 )
 
 var (
@@ -39,7 +40,8 @@ func Open(ctx context.Context, cfg Config, opts ...Option) (*Client, error) {
 		PoolReadyThresholdLow:  cfg.poolReadyLo,
 	})
 
-	client.queryCtx = qq.NewCtx(client.logger, client.querySvc, cfg.txSettings, cfg.queryTimeout)
+	client.queryCtx = qq.NewCtx(client.logger, client.querySvc, cfg.txSettings, cfg.queryTimeout, cfg.retryAttempts)
+	client.scripts = scripts.New(client.logger, client.dispatcher.Transport(), cfg.retryAttempts)
 
 	client.wg.Add(1)
 	go client.dispatcher.Run(runCtx, client.wg)
@@ -68,6 +70,7 @@ type (
 		querySvc     *query.Service
 
 		queryCtx *qq.Ctx
+		scripts  *scripts.Client
 
 		wg     *sync.WaitGroup
 		cancel context.CancelFunc
@@ -80,6 +83,12 @@ func (c *Client) QueryCtx() *qq.Ctx {
 	return c.queryCtx
 }
 
+// Scripts returns client which executes long-running scripts.
+// Scripts are not limited by query timeout.
+func (c *Client) Scripts() *scripts.Client {
+	return c.scripts
+}
+
 func (c *Client) Close() {
 	c.cancel()
 	_ = c.querySvc.Close()
@@ -4,6 +4,7 @@ package ydbgoquery
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -218,6 +219,9 @@ func TestClient(t *testing.T) {
 				t.Run("Finished", func(t *testing.T) {
 					testTransactionFinished(t, tt.config, tt.options, tt.timeout)
 				})
+				t.Run("DoTx", func(t *testing.T) {
+					testDoTx(t, tt.config, tt.options, tt.timeout)
+				})
 			})
 		})
 	}
@@ -367,12 +371,63 @@ func testTransactions(t *testing.T, cfg Config, opts []Option, timeout time.Dura
 	dropUsersTable(ctx, t, qCtx)
 }
 
+func testDoTx(t *testing.T, cfg Config, opts []Option, timeout time.Duration) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	opts = append(opts,
+		WithZeroLogger(zeroLogger, logLevel),
+		WithQueryTimeout(5*time.Second))
+
+	client, err := Open(ctx, cfg, opts...)
+	require.NoError(t, err)
+	defer client.Close()
+
+	qCtx := client.QueryCtx()
+
+	prepareUsersTable(ctx, t, qCtx)
+
+	// committed tx
+	err = qCtx.DoTx(ctx, func(ctx context.Context, tx *query.Transaction) error {
+		for i := 0; i < 10; i++ {
+			res, errTx := txQuery(tx).Exec(ctx)
+			verifyResult(t, res, errTx)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assertUsers(ctx, t, qCtx, 10)
+
+	// rolled back tx
+	errRollback := errors.New("rollback")
+	err = qCtx.DoTx(ctx, func(ctx context.Context, tx *query.Transaction) error {
+		res, errTx := txQuery(tx).Exec(ctx)
+		verifyResult(t, res, errTx)
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	assertUsers(ctx, t, qCtx, 10)
+
+	// inline commit
+	err = qCtx.DoTx(ctx, func(ctx context.Context, tx *query.Transaction) error {
+		res, errTx := txQuery(tx).Commit().Exec(ctx)
+		verifyResult(t, res, errTx)
+		return nil
+	}, query.WithTxMaxAttempts(1))
+	require.NoError(t, err)
+	assertUsers(ctx, t, qCtx, 11)
+
+	dropUsersTable(ctx, t, qCtx)
+}
+
 func assertUsers(ctx context.Context, t *testing.T, qCtx *query.Ctx, usersCount int) {
 	t.Helper()
 
 	usrCtr := 0
 	res, err := qCtx.Query("SELECT * FROM users").
-		Collect(func(rows []*Ydb.Value) error {
+		Collect(func(_ int64, _ []*Ydb.Column, rows []*Ydb.Value) error {
 			for range rows {
 				usrCtr++
 			}
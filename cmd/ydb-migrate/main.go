@@ -0,0 +1,193 @@
+// Command ydb-migrate applies versioned YQL migrations from directory.
+//
+// Usage:
+//
+//	ydb-migrate [flags] up
+//	ydb-migrate [flags] down <version>
+//	ydb-migrate [flags] status
+//
+// Connection flags map to ydbgoquery.Config and options accepted by ydbgoquery.Open.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	ydb "github.com/adwski/ydb-go-query"
+	"github.com/adwski/ydb-go-query/migrate"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	exitUsage = 2
+)
+
+var (
+	errUsage = errors.New("usage error")
+)
+
+type flags struct {
+	nodes     string
+	db        string
+	dir       string
+	table     string
+	user      string
+	ycKeyFile string
+	logLevel  string
+	lockTTL   time.Duration
+	timeout   time.Duration
+	tls       bool
+}
+
+func main() {
+	var f flags
+
+	fs := flag.NewFlagSet("ydb-migrate", flag.ExitOnError)
+	fs.StringVar(&f.nodes, "nodes", "127.0.0.1:2136", "comma separated initial nodes used for discovery")
+	fs.StringVar(&f.db, "db", "/local", "database path")
+	fs.StringVar(&f.dir, "dir", "migrations", "directory with migration files")
+	fs.StringVar(&f.table, "table", "schema_migrations", "versions table name")
+	fs.StringVar(&f.user, "user", "", "username, password is read from YDB_PASSWORD env")
+	fs.StringVar(&f.ycKeyFile, "yc-key-file", "", "Yandex Cloud IAM key file")
+	fs.StringVar(&f.logLevel, "log-level", "error", "log level")
+	fs.DurationVar(&f.lockTTL, "lock-ttl", 10*time.Minute, "migration lock lease duration")
+	fs.DurationVar(&f.timeout, "timeout", 30*time.Minute, "overall timeout")
+	fs.BoolVar(&f.tls, "tls", false, "use TLS transport")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: ydb-migrate [flags] up | down <version> | status\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(os.Args[1:])
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, f, fs.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, errUsage) {
+			fs.Usage()
+			os.Exit(exitUsage)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, f flags, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	client, err := ydb.Open(ctx, ydb.Config{
+		InitialNodes: strings.Split(f.nodes, ","),
+		DB:           f.db,
+	}, options(f)...)
+	if err != nil {
+		return err //nolint:wrapcheck // unnecessary
+	}
+	defer client.Close()
+
+	m, err := migrate.New(client.QueryCtx(), os.DirFS(f.dir), ".",
+		migrate.WithTable(f.table),
+		migrate.WithLockTTL(f.lockTTL))
+	if err != nil {
+		return err //nolint:wrapcheck // unnecessary
+	}
+
+	switch args[0] {
+	case "up":
+		done, errUp := m.Up(ctx)
+		for _, mig := range done {
+			fmt.Printf("applied %s\n", mig)
+		}
+		if errUp == nil && len(done) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+		return errUp //nolint:wrapcheck // unnecessary
+
+	case "down":
+		if len(args) != 2 { //nolint:mnd // command and version
+			return errUsage
+		}
+		version, errV := strconv.ParseUint(args[1], 10, 64)
+		if errV != nil {
+			return errors.Join(errUsage, errV)
+		}
+		done, errDown := m.DownTo(ctx, version)
+		for _, mig := range done {
+			fmt.Printf("rolled back %s\n", mig)
+		}
+
+		return errDown //nolint:wrapcheck // unnecessary
+
+	case "status":
+		st, errSt := m.Status(ctx)
+		if errSt != nil {
+			return errSt //nolint:wrapcheck // unnecessary
+		}
+		printStatus(st)
+
+		return nil
+	}
+
+	return errUsage
+}
+
+func options(f flags) []ydb.Option {
+	opts := []ydb.Option{
+		ydb.WithZeroLogger(zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}), f.logLevel),
+	}
+
+	// transport security must be set before auth options
+	if f.tls {
+		opts = append(opts, ydb.WithTransportTLS())
+	}
+
+	switch {
+	case f.ycKeyFile != "":
+		opts = append(opts, ydb.WithYCAuthFile(f.ycKeyFile))
+	case f.user != "":
+		opts = append(opts, ydb.WithUserPass(f.user, os.Getenv("YDB_PASSWORD")))
+	}
+
+	return opts
+}
+
+func printStatus(st []migrate.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0) //nolint:mnd // padding
+	_, _ = fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+
+	for _, s := range st {
+		state := "pending"
+		switch {
+		case s.Missing:
+			state = "missing"
+		case s.Drifted():
+			state = "drifted"
+		case s.Applied:
+			state = "applied"
+		}
+
+		appliedAt := ""
+		if s.Applied {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		_, _ = fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+	}
+
+	_ = w.Flush()
+}
@@ -11,6 +11,7 @@ import (
 	zaplogger "github.com/adwski/ydb-go-query/internal/logger/zap"
 	zerologger "github.com/adwski/ydb-go-query/internal/logger/zerolog"
 	"github.com/adwski/ydb-go-query/internal/query/txsettings"
+	"github.com/adwski/ydb-go-query/internal/retry"
 	"github.com/adwski/ydb-go-query/internal/transport/auth"
 	"github.com/adwski/ydb-go-query/internal/transport/auth/userpass"
 	"github.com/adwski/ydb-go-query/internal/transport/auth/yc"
@@ -52,6 +53,7 @@ type (
 		poolReadyLo uint
 
 		connectionsPerEndpoint int
+		retryAttempts          int
 
 		sessionCreateTimeout time.Duration
 		queryTimeout         time.Duration
@@ -65,6 +67,7 @@ func (cfg *Config) setDefaults() {
 	cfg.queryTimeout = defaultQueryTimeout
 	cfg.poolSize = defaultSessionPoolSize
 	cfg.connectionsPerEndpoint = defaultConnectionsPerEndpoint
+	cfg.retryAttempts = retry.DefaultMaxAttempts
 	cfg.transportCredentials = transportCreds.Insecure()
 	cfg.txSettings = txsettings.SerializableReadWrite()
 }
@@ -151,6 +154,18 @@ func WithConnectionsPerEndpoint(connections int) Option {
 	}
 }
 
+// WithRetryMaxAttempts limits amount of attempts for retried operations.
+// 1 disables retries, 0 means attempts are limited only by context.
+func WithRetryMaxAttempts(attempts int) Option {
+	return func(ctx context.Context, cfg *Config) error {
+		if attempts >= 0 {
+			cfg.retryAttempts = attempts
+		}
+
+		return nil
+	}
+}
+
 func withTransportSecurity(credentials credentials.TransportCredentials) Option {
 	return func(ctx context.Context, cfg *Config) error {
 		cfg.transportCredentials = credentials
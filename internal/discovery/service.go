@@ -3,12 +3,12 @@ package discovery
 import (
 	"context"
 	"errors"
-	"fmt"
 	"sync"
 	"time"
 
 	"github.com/adwski/ydb-go-query/internal/endpoints"
 	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/ydberr"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Discovery_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -142,7 +142,7 @@ func (svc *Service) getEndpoints(ctx context.Context) ([]*Ydb_Discovery.Endpoint
 	status := resp.GetOperation().GetStatus()
 	if status != Ydb.StatusIds_SUCCESS {
 		return nil, errors.Join(ErrOperationUnsuccessful,
-			fmt.Errorf("%s", resp.GetOperation().String()))
+			ydberr.NewOperationError(status, resp.GetOperation().GetIssues()))
 	}
 	var epRes Ydb_Discovery.ListEndpointsResult
 	if err = resp.GetOperation().GetResult().UnmarshalTo(&epRes); err != nil {
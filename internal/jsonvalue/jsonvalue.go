@@ -0,0 +1,83 @@
+// Package jsonvalue converts Go values decoded from YDB values to form which can be encoded to JSON.
+package jsonvalue
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+)
+
+// Options configures conversion of values which have no JSON counterpart.
+// Zero value is usable.
+type Options struct {
+	// EncodeBytes encodes String and Yson values, standard base64 is used if nil.
+	EncodeBytes func([]byte) string
+
+	// TimeLayout is a layout of date and time values, time.RFC3339Nano by default.
+	TimeLayout string
+}
+
+// Convert converts decoded value to form which can be encoded to JSON:
+// times, durations and bytes are formatted according to options, dict keys
+// are converted to strings, variants to objects with single key, and non-finite floats to strings.
+// Lists and structs are converted in place.
+//
+//nolint:cyclop // flat switch over decoded types
+func Convert(v any, opts *Options) any {
+	switch tv := v.(type) {
+	case time.Time:
+		layout := opts.TimeLayout
+		if layout == "" {
+			layout = time.RFC3339Nano
+		}
+		return tv.Format(layout)
+	case time.Duration:
+		return tv.String()
+	case []byte:
+		if opts.EncodeBytes != nil {
+			return opts.EncodeBytes(tv)
+		}
+		return base64.StdEncoding.EncodeToString(tv)
+	case float64:
+		if math.IsNaN(tv) || math.IsInf(tv, 0) {
+			return strconv.FormatFloat(tv, 'g', -1, 64)
+		}
+	case []any:
+		for i := range tv {
+			tv[i] = Convert(tv[i], opts)
+		}
+	case map[string]any:
+		for k := range tv {
+			tv[k] = Convert(tv[k], opts)
+		}
+	case map[any]any:
+		m := make(map[string]any, len(tv))
+		for k, item := range tv {
+			key := Convert(k, opts)
+			if s, ok := key.(string); ok {
+				m[s] = Convert(item, opts)
+			} else {
+				m[fmt.Sprint(key)] = Convert(item, opts)
+			}
+		}
+		return m
+	case types.VariantValue:
+		name := tv.Name
+		if name == "" {
+			name = strconv.FormatUint(uint64(tv.Index), 10)
+		}
+		return map[string]any{name: Convert(tv.Value, opts)}
+	}
+
+	return v
+}
+
+// Marshal encodes decoded value to JSON with default options.
+func Marshal(v any) ([]byte, error) {
+	return json.Marshal(Convert(v, &Options{})) //nolint:wrapcheck // wrapped by caller
+}
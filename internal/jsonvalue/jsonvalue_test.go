@@ -0,0 +1,33 @@
+package jsonvalue
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	b, err := Marshal(types.VariantValue{Name: "b", Value: []byte("x")})
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":"eA=="}`, string(b))
+
+	b, err = Marshal(map[any]any{uint64(1): []any{time.Second, math.Inf(1)}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"1":["1s","+Inf"]}`, string(b))
+}
+
+func TestConvert(t *testing.T) {
+	opts := &Options{EncodeBytes: hex.EncodeToString, TimeLayout: time.DateOnly}
+	assert.Equal(t, map[string]any{
+		"0": map[string]any{"at": "2024-09-20", "data": "78"},
+	}, Convert(types.VariantValue{Value: map[string]any{
+		"at":   time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC),
+		"data": []byte("x"),
+	}}, opts))
+}
@@ -0,0 +1,26 @@
+// Package naming provides conversions of Go identifiers to YDB names.
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Snake converts CamelCase name to snake_case, acronyms are kept together: UserID -> user_id.
+func Snake(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
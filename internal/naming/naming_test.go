@@ -0,0 +1,19 @@
+package naming
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnake(t *testing.T) {
+	for in, out := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"CreatedAt": "created_at",
+		"HTTPCode":  "http_code",
+		"name":      "name",
+	} {
+		assert.Equal(t, out, Snake(in), in)
+	}
+}
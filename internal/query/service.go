@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/adwski/ydb-go-query/internal/logger"
@@ -78,17 +79,19 @@ func (svc *Service) AcquireSession(ctx context.Context) (*session.Session, func(
 }
 
 // Exec provides low-level single query execution.
+// Session is held until returned cancel func is called,
+// so cancel must be called once result stream is not needed anymore.
 func (svc *Service) Exec(
 	ctx context.Context,
 	query string,
 	params map[string]*Ydb.TypedValue,
 	txSettings *Ydb_Query.TransactionSettings,
+	opts session.ExecOptions,
 ) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error) {
 	sess, cleanup, err := svc.AcquireSession(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer cleanup()
 
 	var txControl *Ydb_Query.TransactionControl
 	if txSettings != nil {
@@ -100,12 +103,20 @@ func (svc *Service) Exec(
 		}
 	}
 
-	stream, cancel, err := sess.Exec(ctx, query, params, txControl)
+	stream, cancel, err := sess.Exec(ctx, query, params, txControl, opts)
 	if err != nil {
+		cleanup()
 		return nil, nil, errors.Join(ErrExec, err)
 	}
 
-	return stream, cancel, nil
+	once := &sync.Once{}
+
+	return stream, func() {
+		once.Do(func() {
+			cancel()
+			cleanup()
+		})
+	}, nil
 }
 
 func (svc *Service) Ready() bool {
@@ -3,7 +3,6 @@ package session
 import (
 	"context"
 	"errors"
-	"fmt"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -16,6 +15,27 @@ const (
 	defaultExecMode    = Ydb_Query.ExecMode_EXEC_MODE_EXECUTE
 )
 
+type (
+	// ExecOptions holds optional query execution settings.
+	ExecOptions struct {
+		// ConcurrentResultSets allows YDB to send
+		// parts of different result sets interleaved.
+		ConcurrentResultSets bool
+
+		// StatsMode sets query stats collection mode.
+		// Default mode is used if not set.
+		StatsMode Ydb_Query.StatsMode
+
+		// ExecMode sets query execution mode.
+		// Query is executed if not set.
+		ExecMode Ydb_Query.ExecMode
+
+		// Syntax sets query syntax.
+		// YQL is used if not set.
+		Syntax Ydb_Query.Syntax
+	}
+)
+
 var (
 	ErrExec       = errors.New("exec error")
 	ErrTxRollback = errors.New("transaction rollback error")
@@ -29,10 +49,12 @@ func (s *Session) RollbackTX(ctx context.Context, txID string) error {
 		TxId:      txID,
 	})
 	if err != nil {
+		s.checkError(err)
 		return errors.Join(ErrTxRollback, err)
 	}
 	if resp.Status != Ydb.StatusIds_SUCCESS {
-		return errors.Join(ErrTxRollback, fmt.Errorf("status: %s", resp.Status.String()))
+		s.checkStatus(resp.Status)
+		return errors.Join(ErrTxRollback, s.operationError(resp.Status, resp.Issues))
 	}
 
 	return nil
@@ -44,10 +66,12 @@ func (s *Session) CommitTX(ctx context.Context, txID string) error {
 		TxId:      txID,
 	})
 	if err != nil {
+		s.checkError(err)
 		return errors.Join(ErrTxCommit, err)
 	}
 	if resp.Status != Ydb.StatusIds_SUCCESS {
-		return errors.Join(ErrTxCommit, fmt.Errorf("status: %s", resp.Status.String()))
+		s.checkStatus(resp.Status)
+		return errors.Join(ErrTxCommit, s.operationError(resp.Status, resp.Issues))
 	}
 
 	return nil
@@ -58,32 +82,76 @@ func (s *Session) Exec(
 	query string,
 	params map[string]*Ydb.TypedValue,
 	txControl *Ydb_Query.TransactionControl,
+	opts ExecOptions,
 ) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error) {
 	if s.shutdown.Load() {
 		return nil, nil, ErrShutdown
 	}
 
+	execMode := opts.ExecMode
+	if execMode == Ydb_Query.ExecMode_EXEC_MODE_UNSPECIFIED {
+		execMode = defaultExecMode
+	}
+
+	syntax := opts.Syntax
+	if syntax == Ydb_Query.Syntax_SYNTAX_UNSPECIFIED {
+		syntax = defaultQuerySyntax
+	}
+
+	statsMode := opts.StatsMode
+	if statsMode == Ydb_Query.StatsMode_STATS_MODE_UNSPECIFIED {
+		statsMode = defaultStatsMode
+	}
+
 	streamCtx, cancelStream := context.WithCancel(ctx)
 
 	respExec, err := s.qsc.ExecuteQuery(streamCtx, &Ydb_Query.ExecuteQueryRequest{
 		SessionId: s.id,
-		ExecMode:  defaultExecMode,
+		ExecMode:  execMode,
 		TxControl: txControl,
 		Query: &Ydb_Query.ExecuteQueryRequest_QueryContent{
 			QueryContent: &Ydb_Query.QueryContent{
-				Syntax: defaultQuerySyntax,
+				Syntax: syntax,
 				Text:   query,
 			},
 		},
 		Parameters:           params,
-		StatsMode:            defaultStatsMode,
-		ConcurrentResultSets: false,
+		StatsMode:            statsMode,
+		ConcurrentResultSets: opts.ConcurrentResultSets,
 	})
 
 	if err != nil {
 		cancelStream()
+		s.checkError(err)
 		return nil, nil, errors.Join(ErrExec, err)
 	}
 
-	return respExec, cancelStream, nil
+	return &resultStream{
+		QueryService_ExecuteQueryClient: respExec,
+		sess:                            s,
+	}, cancelStream, nil
+}
+
+// resultStream inspects statuses of received result parts
+// and invalidates session if necessary.
+type resultStream struct {
+	Ydb_Query_V1.QueryService_ExecuteQueryClient
+
+	sess *Session
+}
+
+// Origin returns endpoint and node id of the session which executes query.
+func (rs *resultStream) Origin() (string, int64) {
+	return rs.sess.Origin()
+}
+
+func (rs *resultStream) Recv() (*Ydb_Query.ExecuteQueryResponsePart, error) {
+	part, err := rs.QueryService_ExecuteQueryClient.Recv()
+	if err == nil {
+		rs.sess.checkStatus(part.Status)
+	} else {
+		rs.sess.checkError(err)
+	}
+
+	return part, err //nolint:wrapcheck // transparent wrapper
 }
@@ -3,7 +3,6 @@ package session
 import (
 	"context"
 	"errors"
-	"fmt"
 	"hash/maphash"
 	"io"
 	"sync"
@@ -12,9 +11,11 @@ import (
 
 	"github.com/adwski/ydb-go-query/internal/logger"
 	"github.com/adwski/ydb-go-query/internal/xcontext"
+	"github.com/adwski/ydb-go-query/ydberr"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -52,11 +53,12 @@ type (
 		cancelFunc context.CancelFunc
 		done       chan struct{}
 
-		state *Ydb_Query.SessionState
-		err   error
-		id    string
-		id_   uint64
-		node  int64
+		state    *Ydb_Query.SessionState
+		err      error
+		id       string
+		endpoint string
+		id_      uint64
+		node     int64
 
 		shutdown atomic.Bool
 	}
@@ -84,19 +86,24 @@ func CreateSession(
 	if err != nil {
 		return nil, errors.Join(ErrSessionCreate, err)
 	}
-	if respCreate.Status != Ydb.StatusIds_SUCCESS {
-		return nil, errors.Join(ErrSessionCreate, fmt.Errorf("status: %s", respCreate.Status))
-	}
-
 	if transport == nil {
 		return nil, ErrSessionTransport
 	}
 
+	if respCreate.Status != Ydb.StatusIds_SUCCESS {
+		opErr := ydberr.NewOperationError(respCreate.Status, respCreate.Issues)
+		opErr.Endpoint = endpointOf(transport)
+		opErr.NodeID = respCreate.GetNodeId()
+
+		return nil, errors.Join(ErrSessionCreate, opErr)
+	}
+
 	sess := &Session{
 		logger:    logger,
 		transport: transport,
 		qsc:       Ydb_Query_V1.NewQueryServiceClient(transport),
 		id:        respCreate.GetSessionId(),
+		endpoint:  endpointOf(transport),
 		id_:       maphash.String(hashSeed, respCreate.GetSessionId()),
 		node:      respCreate.GetNodeId(),
 		done:      make(chan struct{}),
@@ -138,6 +145,40 @@ func (s *Session) Close() error {
 	return err
 }
 
+// Origin returns endpoint and node id of the session.
+func (s *Session) Origin() (string, int64) {
+	return s.endpoint, s.node
+}
+
+// operationError creates error for unsuccessful status received within session.
+func (s *Session) operationError(
+	code Ydb.StatusIds_StatusCode,
+	issues []*Ydb_Issue.IssueMessage,
+) *ydberr.OperationError {
+	opErr := ydberr.NewOperationError(code, issues)
+	opErr.Endpoint, opErr.NodeID = s.Origin()
+
+	return opErr
+}
+
+// checkStatus marks session as not alive if status indicates
+// that session cannot be used anymore. Pool will replace such session.
+func (s *Session) checkStatus(code Ydb.StatusIds_StatusCode) {
+	if ydberr.ClassifyStatus(code).DeleteSession {
+		s.shutdown.Store(true)
+		s.logger.Debug("session invalidated", "id", s.id, "status", code)
+	}
+}
+
+// checkError marks session as not alive if transport error
+// indicates that session cannot be used anymore.
+func (s *Session) checkError(err error) {
+	if ydberr.Classify(err).DeleteSession {
+		s.shutdown.Store(true)
+		s.logger.Debug("session invalidated", "id", s.id, "error", err)
+	}
+}
+
 func (s *Session) attachStream(ctx context.Context) error {
 	attachCtx, streamCancel := context.WithCancel(ctx)
 
@@ -214,9 +255,17 @@ func (s *Session) cleanup(ctx context.Context) error {
 		return errors.Join(ErrSessionDelete, err)
 	}
 	if respDelete.Status != Ydb.StatusIds_SUCCESS {
-		return errors.Join(ErrSessionDelete,
-			fmt.Errorf("status: %s", respDelete.Status))
+		return errors.Join(ErrSessionDelete, s.operationError(respDelete.Status, respDelete.Issues))
 	}
 
 	return nil
 }
+
+// endpointOf returns address of the endpoint which transport is connected to.
+func endpointOf(transport grpc.ClientConnInterface) string {
+	if ep, ok := transport.(interface{ Endpoint() string }); ok {
+		return ep.Endpoint()
+	}
+
+	return ""
+}
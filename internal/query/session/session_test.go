@@ -0,0 +1,145 @@
+package session
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeQueryService fails all calls with err, result stream fails after parts.
+type fakeQueryService struct {
+	Ydb_Query_V1.QueryServiceClient
+
+	err     error
+	execErr error
+	parts   []*Ydb_Query.ExecuteQueryResponsePart
+}
+
+type fakeExecStream struct {
+	grpc.ClientStream
+
+	parts []*Ydb_Query.ExecuteQueryResponsePart
+	err   error
+}
+
+func (f *fakeQueryService) ExecuteQuery(
+	context.Context,
+	*Ydb_Query.ExecuteQueryRequest,
+	...grpc.CallOption,
+) (Ydb_Query_V1.QueryService_ExecuteQueryClient, error) {
+	if f.execErr != nil {
+		return nil, f.execErr
+	}
+
+	return &fakeExecStream{parts: f.parts, err: f.err}, nil
+}
+
+func (f *fakeQueryService) CommitTransaction(
+	context.Context,
+	*Ydb_Query.CommitTransactionRequest,
+	...grpc.CallOption,
+) (*Ydb_Query.CommitTransactionResponse, error) {
+	return nil, f.err
+}
+
+func (f *fakeQueryService) RollbackTransaction(
+	context.Context,
+	*Ydb_Query.RollbackTransactionRequest,
+	...grpc.CallOption,
+) (*Ydb_Query.RollbackTransactionResponse, error) {
+	return nil, f.err
+}
+
+func (fs *fakeExecStream) Recv() (*Ydb_Query.ExecuteQueryResponsePart, error) {
+	if len(fs.parts) == 0 {
+		return nil, fs.err
+	}
+	part := fs.parts[0]
+	fs.parts = fs.parts[1:]
+
+	return part, nil
+}
+
+func newTestSession(qsc Ydb_Query_V1.QueryServiceClient) *Session {
+	return &Session{
+		logger: logger.New(noop.NewLogger()),
+		qsc:    qsc,
+		id:     "test",
+	}
+}
+
+func TestSession_TransportError(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+
+	for name, call := range map[string]func(*Session) error{
+		"commit": func(s *Session) error {
+			return s.CommitTX(context.Background(), "tx")
+		},
+		"rollback": func(s *Session) error {
+			return s.RollbackTX(context.Background(), "tx")
+		},
+		"exec": func(s *Session) error {
+			_, _, err := s.Exec(context.Background(), "select 1", nil, nil, ExecOptions{})
+			return err
+		},
+		"recv": func(s *Session) error {
+			stream, cancel, err := s.Exec(context.Background(), "select 1", nil, nil, ExecOptions{})
+			if err != nil {
+				return err
+			}
+			defer cancel()
+
+			for {
+				if _, err = stream.Recv(); err != nil {
+					return err
+				}
+			}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			qsc := &fakeQueryService{err: unavailable, parts: []*Ydb_Query.ExecuteQueryResponsePart{
+				{Status: Ydb.StatusIds_SUCCESS},
+			}}
+			if name == "exec" {
+				qsc.execErr = unavailable
+			}
+			s := newTestSession(qsc)
+
+			err := call(s)
+			require.ErrorIs(t, err, unavailable)
+			assert.False(t, s.Alive())
+
+			// session is not used anymore
+			_, _, err = s.Exec(context.Background(), "select 1", nil, nil, ExecOptions{})
+			require.ErrorIs(t, err, ErrShutdown)
+		})
+	}
+}
+
+func TestSession_TransportErrorAlive(t *testing.T) {
+	// errors which do not affect session leave it alive
+	s := newTestSession(&fakeQueryService{err: status.Error(codes.InvalidArgument, "bad request")})
+	require.Error(t, s.CommitTX(context.Background(), "tx"))
+	assert.True(t, s.Alive())
+
+	// end of result stream is not an error
+	s = newTestSession(&fakeQueryService{err: io.EOF})
+	stream, cancel, err := s.Exec(context.Background(), "select 1", nil, nil, ExecOptions{})
+	require.NoError(t, err)
+	defer cancel()
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, io.EOF)
+	assert.True(t, s.Alive())
+}
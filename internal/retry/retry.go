@@ -0,0 +1,103 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/ydberr"
+)
+
+const (
+	fastBackoffBase    = 5 * time.Millisecond
+	fastBackoffCeiling = 500 * time.Millisecond
+	slowBackoffBase    = time.Second
+	slowBackoffCeiling = 30 * time.Second
+
+	maxBackoffShift = 16
+
+	DefaultMaxAttempts = 10
+)
+
+type Config struct {
+	Logger logger.Logger
+
+	// MaxAttempts limits total amount of op calls.
+	// Zero value means attempts are limited only by context.
+	MaxAttempts int
+
+	// Idempotent allows to retry errors which leave
+	// operation in undetermined state.
+	Idempotent bool
+}
+
+// Do calls op until it succeeds, returns non-retryable error,
+// attempts are exhausted or ctx is done.
+// Do never sleeps beyond ctx deadline, in such case last error is returned immediately.
+func Do(ctx context.Context, cfg Config, op func(context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := op(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+		class := ydberr.Classify(err)
+		if !class.Retryable(cfg.Idempotent) {
+			return err
+		}
+		if cfg.MaxAttempts > 0 && attempt+1 >= cfg.MaxAttempts {
+			return err
+		}
+
+		delay := Delay(class.Backoff, attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+
+		cfg.Logger.Debug("retrying operation",
+			"attempt", attempt+1,
+			"delay", delay,
+			"error", err)
+
+		if delay == 0 {
+			continue
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Delay returns delay before next attempt.
+// Delay grows exponentially with attempt number up to backoff ceiling,
+// half of it is randomized to spread concurrent retries.
+func Delay(b ydberr.Backoff, attempt int) time.Duration {
+	var base, ceiling time.Duration
+	switch b {
+	case ydberr.BackoffFast:
+		base, ceiling = fastBackoffBase, fastBackoffCeiling
+	case ydberr.BackoffSlow:
+		base, ceiling = slowBackoffBase, slowBackoffCeiling
+	default:
+		return 0
+	}
+
+	d := base << min(attempt, maxBackoffShift)
+	if d <= 0 || d > ceiling {
+		d = ceiling
+	}
+
+	half := d / 2
+
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec // jitter
+}
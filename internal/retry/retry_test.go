@@ -0,0 +1,126 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func testConfig() Config {
+	return Config{
+		Logger:      logger.New(noop.NewLogger()),
+		MaxAttempts: 5,
+	}
+}
+
+func TestDo_Success(t *testing.T) {
+	var calls int
+	err := Do(context.Background(), testConfig(), func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return ydberr.NewOperationError(Ydb.StatusIds_ABORTED, nil)
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestDo_NonRetryable(t *testing.T) {
+	var calls int
+	err := Do(context.Background(), testConfig(), func(ctx context.Context) error {
+		calls++
+		return ydberr.NewOperationError(Ydb.StatusIds_SCHEME_ERROR, nil)
+	})
+
+	assert.True(t, ydberr.IsStatus(err, Ydb.StatusIds_SCHEME_ERROR))
+	assert.Equal(t, 1, calls)
+}
+
+func TestDo_Idempotent(t *testing.T) {
+	cfg := testConfig()
+
+	var calls int
+	op := func(ctx context.Context) error {
+		calls++
+		return ydberr.NewOperationError(Ydb.StatusIds_UNDETERMINED, nil)
+	}
+
+	assert.Error(t, Do(context.Background(), cfg, op))
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	cfg.Idempotent = true
+	assert.Error(t, Do(context.Background(), cfg, op))
+	assert.Equal(t, cfg.MaxAttempts, calls)
+}
+
+func TestDo_MaxAttempts(t *testing.T) {
+	var calls int
+	err := Do(context.Background(), testConfig(), func(ctx context.Context) error {
+		calls++
+		return ydberr.NewOperationError(Ydb.StatusIds_BAD_SESSION, nil)
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 5, calls)
+}
+
+func TestDo_Deadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	cfg := testConfig()
+	cfg.MaxAttempts = 0
+
+	var calls int
+	start := time.Now()
+	err := Do(ctx, cfg, func(ctx context.Context) error {
+		calls++
+		// slow backoff is longer than deadline
+		return ydberr.NewOperationError(Ydb.StatusIds_OVERLOADED, nil)
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestDo_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	err := Do(ctx, testConfig(), func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.Join(ctx.Err(), ydberr.NewOperationError(Ydb.StatusIds_ABORTED, nil))
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	assert.Zero(t, Delay(ydberr.BackoffNone, 10))
+
+	for attempt := 0; attempt < 100; attempt++ {
+		d := Delay(ydberr.BackoffFast, attempt)
+		assert.GreaterOrEqual(t, d, fastBackoffBase/2)
+		assert.LessOrEqual(t, d, fastBackoffCeiling)
+
+		d = Delay(ydberr.BackoffSlow, attempt)
+		assert.GreaterOrEqual(t, d, slowBackoffBase/2)
+		assert.LessOrEqual(t, d, slowBackoffCeiling)
+	}
+
+	assert.GreaterOrEqual(t, Delay(ydberr.BackoffSlow, maxBackoffShift), slowBackoffCeiling/2)
+}
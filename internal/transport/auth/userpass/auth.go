@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/adwski/ydb-go-query/ydberr"
+
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Auth_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Auth"
@@ -62,8 +64,13 @@ func (up *UserPass) GetToken(ctx context.Context) (token string, expires time.Ti
 		err = errors.Join(ErrLogin, ErrNilOperation)
 		return
 	}
-	if op.GetStatus() == Ydb.StatusIds_UNAUTHORIZED {
-		err = ErrUnauthorized
+	switch op.GetStatus() {
+	case Ydb.StatusIds_SUCCESS:
+	case Ydb.StatusIds_UNAUTHORIZED:
+		err = errors.Join(ErrUnauthorized, ydberr.NewOperationError(op.GetStatus(), op.GetIssues()))
+		return
+	default:
+		err = errors.Join(ErrLogin, ydberr.NewOperationError(op.GetStatus(), op.GetIssues()))
 		return
 	}
 	var result Ydb_Auth.LoginResult
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/adwski/ydb-go-query/ydberr"
+
 	ycsdk "github.com/yandex-cloud/go-sdk"
 	"github.com/yandex-cloud/go-sdk/iamkey"
 )
@@ -71,7 +73,7 @@ func New(ctx context.Context, cfg Config) (*YC, error) {
 func (a *YC) GetToken(ctx context.Context) (token string, expires time.Time, err error) {
 	tokenResp, err := a.sdk.CreateIAMToken(ctx)
 	if err != nil {
-		err = errors.Join(ErrIAMTokenCreate, err)
+		err = errors.Join(ErrIAMTokenCreate, ydberr.NewTransportError(err, ""))
 
 		return
 	}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"github.com/adwski/ydb-go-query/ydberr"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
@@ -64,6 +66,11 @@ func (c *Connection) ID() uint64 {
 	return c.endpointID
 }
 
+// Endpoint returns address of the endpoint.
+func (c *Connection) Endpoint() string {
+	return c.Target()
+}
+
 func (c *Connection) Close() error {
 	if err := c.ClientConn.Close(); err != nil {
 		return errors.Join(ErrClose, err)
@@ -98,7 +105,7 @@ func (c *Connection) Invoke(ctx context.Context, method string, args any, reply
 	if err != nil {
 		return err
 	}
-	return c.ClientConn.Invoke(callCtx, method, args, reply, opts...) //nolint:wrapcheck // unnecessary
+	return ydberr.NewTransportError(c.ClientConn.Invoke(callCtx, method, args, reply, opts...), c.Target())
 }
 
 func (c *Connection) NewStream(
@@ -111,5 +118,25 @@ func (c *Connection) NewStream(
 	if err != nil {
 		return nil, err
 	}
-	return c.ClientConn.NewStream(callCtx, desc, method, opts...) //nolint:wrapcheck // unnecessary
+	stream, err := c.ClientConn.NewStream(callCtx, desc, method, opts...)
+	if err != nil {
+		return nil, ydberr.NewTransportError(err, c.Target())
+	}
+
+	return &clientStream{ClientStream: stream, endpoint: c.Target()}, nil
+}
+
+// clientStream wraps stream errors with ydberr.TransportError.
+type clientStream struct {
+	grpc.ClientStream
+
+	endpoint string
+}
+
+func (cs *clientStream) SendMsg(m any) error {
+	return ydberr.NewTransportError(cs.ClientStream.SendMsg(m), cs.endpoint)
+}
+
+func (cs *clientStream) RecvMsg(m any) error {
+	return ydberr.NewTransportError(cs.ClientStream.RecvMsg(m), cs.endpoint)
 }
@@ -8,6 +8,7 @@ import (
 
 type (
 	transportPtr struct{}
+	retryScope   struct{}
 )
 
 func WithTransportPtr(ctx context.Context, epPtr *grpc.ClientConnInterface) context.Context {
@@ -22,3 +23,14 @@ func GetTransportPtr(ctx context.Context) *grpc.ClientConnInterface {
 
 	return nil
 }
+
+// WithRetryScope marks context as already being used by retry loop,
+// so nested operations should not be retried on their own.
+func WithRetryScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryScope{}, true)
+}
+
+func InRetryScope(ctx context.Context) bool {
+	inScope, _ := ctx.Value(retryScope{}).(bool)
+	return inScope
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"context"
+
+	"github.com/adwski/ydb-go-query/query"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+type (
+	// executor executes queries of migrator.
+	executor interface {
+		// exec executes query outside of transaction. Query without parameters
+		// is executed without transaction control, so it can contain schema statements.
+		exec(ctx context.Context, content string, params map[string]*Ydb.TypedValue) (*rowSet, error)
+
+		// tx runs op in serializable read-write transaction, op is repeated on retryable errors.
+		tx(ctx context.Context, op func(context.Context, txExecutor) error) error
+	}
+
+	// txExecutor executes queries within transaction.
+	txExecutor interface {
+		// exec executes query in transaction, if commit is true transaction is committed with it.
+		exec(ctx context.Context, content string, params map[string]*Ydb.TypedValue, commit bool) (*rowSet, error)
+	}
+
+	// rowSet holds rows of the first result set of query.
+	rowSet struct {
+		cols []*Ydb.Column
+		rows []*Ydb.Value
+	}
+
+	// queryExecutor executes queries with query context.
+	queryExecutor struct {
+		qc *query.Ctx
+	}
+
+	// txQueryExecutor executes queries with transaction of DoTx.
+	txQueryExecutor struct {
+		tx *query.Transaction
+	}
+)
+
+func (e queryExecutor) exec(ctx context.Context, content string, params map[string]*Ydb.TypedValue) (*rowSet, error) {
+	if params == nil {
+		return resultRows(e.qc.Exec(ctx, content))
+	}
+
+	return resultRows(e.qc.Query(content).Params(params).AutoDeclare(true).Exec(ctx))
+}
+
+func (e queryExecutor) tx(ctx context.Context, op func(context.Context, txExecutor) error) error {
+	return e.qc.SerializableReadWrite().DoTx(ctx, func(ctx context.Context, tx *query.Transaction) error {
+		return op(ctx, txQueryExecutor{tx: tx})
+	})
+}
+
+func (e txQueryExecutor) exec(
+	ctx context.Context,
+	content string,
+	params map[string]*Ydb.TypedValue,
+	commit bool,
+) (*rowSet, error) {
+	q := e.tx.Query(content)
+	if params != nil {
+		q.Params(params).AutoDeclare(true)
+	}
+	if commit {
+		q.Commit()
+	}
+
+	return resultRows(q.Exec(ctx))
+}
+
+func resultRows(res *query.Result, err error) (*rowSet, error) {
+	if err != nil {
+		return nil, err //nolint:wrapcheck // unnecessary
+	}
+	if err = res.Err(); err != nil {
+		return nil, err //nolint:wrapcheck // unnecessary
+	}
+
+	return &rowSet{cols: res.Cols(), rows: res.Rows()}, nil
+}
@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var errFake = errors.New("fake query failed")
+
+type (
+	// fakeDB is an in-memory executor which understands queries of migrator
+	// with default table names. Other queries are recorded as migration contents.
+	// Changes made in transaction are discarded if transaction fails.
+	fakeDB struct {
+		now      time.Time
+		lock     *fakeLock
+		versions map[uint64]appliedRow
+		executed []string
+
+		// failVersion fails version record of migration
+		failVersion uint64
+
+		lockUpserts int
+	}
+
+	fakeLock struct {
+		expiresAt time.Time
+		owner     string
+	}
+
+	fakeTx struct {
+		db *fakeDB
+	}
+)
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		now:      time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC),
+		versions: make(map[uint64]appliedRow),
+	}
+}
+
+func (db *fakeDB) exec(_ context.Context, content string, params map[string]*Ydb.TypedValue) (*rowSet, error) {
+	return db.run(content, params)
+}
+
+func (db *fakeDB) tx(ctx context.Context, op func(context.Context, txExecutor) error) error {
+	txDB := *db
+	txDB.versions = maps.Clone(db.versions)
+	txDB.executed = slices.Clone(db.executed)
+	if db.lock != nil {
+		lock := *db.lock
+		txDB.lock = &lock
+	}
+
+	if err := op(ctx, fakeTx{db: &txDB}); err != nil {
+		return err
+	}
+	*db = txDB
+
+	return nil
+}
+
+func (tx fakeTx) exec(_ context.Context, content string, params map[string]*Ydb.TypedValue, _ bool) (*rowSet, error) {
+	return tx.db.run(content, params)
+}
+
+func (db *fakeDB) run(content string, params map[string]*Ydb.TypedValue) (*rowSet, error) {
+	stmt := strings.TrimSpace(content)
+	switch {
+	case strings.HasPrefix(stmt, "CREATE TABLE IF NOT EXISTS"):
+		return &rowSet{}, nil
+
+	case strings.HasPrefix(stmt, "SELECT owner"):
+		rs := &rowSet{cols: []*Ydb.Column{
+			{Name: "owner", Type: types.UTF8("").Type},
+			{Name: "active", Type: types.Bool(false).Type},
+		}}
+		if db.lock != nil {
+			rs.rows = append(rs.rows, &Ydb.Value{Items: []*Ydb.Value{
+				types.UTF8(db.lock.owner).Value,
+				types.Bool(db.lock.expiresAt.After(db.now)).Value,
+			}})
+		}
+		return rs, nil
+
+	case strings.HasPrefix(stmt, "UPSERT INTO `schema_migrations_lock`"):
+		ttl, err := types.DecodeDuration(params["$ttl"].Type, params["$ttl"].Value)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // test
+		}
+		db.lock = &fakeLock{owner: params["$owner"].Value.GetTextValue(), expiresAt: db.now.Add(ttl)}
+		db.lockUpserts++
+		return &rowSet{}, nil
+
+	case strings.HasPrefix(stmt, "DELETE FROM `schema_migrations_lock`"):
+		if db.lock != nil && db.lock.owner == params["$owner"].Value.GetTextValue() {
+			db.lock = nil
+		}
+		return &rowSet{}, nil
+
+	case strings.HasPrefix(stmt, "SELECT version"):
+		ts, err := types.Timestamp(db.now)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // test
+		}
+		rs := &rowSet{cols: []*Ydb.Column{
+			{Name: "version", Type: types.Uint64(0).Type},
+			{Name: "name", Type: types.UTF8("").Type},
+			{Name: "checksum", Type: types.UTF8("").Type},
+			{Name: "applied_at", Type: ts.Type},
+		}}
+		for _, version := range slices.Sorted(maps.Keys(db.versions)) {
+			row := db.versions[version]
+			rs.rows = append(rs.rows, &Ydb.Value{Items: []*Ydb.Value{
+				types.Uint64(row.Version).Value,
+				types.UTF8(row.Name).Value,
+				types.UTF8(row.Checksum).Value,
+				ts.Value,
+			}})
+		}
+		return rs, nil
+
+	case strings.HasPrefix(stmt, "UPSERT INTO `schema_migrations`"):
+		version := params["$version"].Value.GetUint64Value()
+		if version == db.failVersion {
+			return nil, errFake
+		}
+		db.versions[version] = appliedRow{
+			Version:   version,
+			Name:      params["$name"].Value.GetTextValue(),
+			Checksum:  params["$checksum"].Value.GetTextValue(),
+			AppliedAt: db.now,
+		}
+		return &rowSet{}, nil
+
+	case strings.HasPrefix(stmt, "DELETE FROM `schema_migrations`"):
+		delete(db.versions, params["$version"].Value.GetUint64Value())
+		return &rowSet{}, nil
+	}
+
+	db.executed = append(db.executed, content)
+
+	return &rowSet{}, nil
+}
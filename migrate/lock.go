@@ -0,0 +1,84 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const (
+	lockID = "migrate"
+
+	unlockTimeout = 5 * time.Second
+)
+
+var (
+	ErrLocked = errors.New("migrations are locked by another process")
+)
+
+// lock acquires or extends lock lease. Lock is acquired if it is not held,
+// its lease is expired, or it is already held by this migrator.
+// Check and update are performed in single serializable transaction,
+// so concurrent attempts are resolved by transaction locks.
+func (m *Migrator) lock(ctx context.Context) error {
+	ttl, err := types.Interval(m.lockTTL)
+	if err != nil {
+		return fmt.Errorf("lock ttl: %w", err)
+	}
+
+	return m.ex.tx(ctx, func(ctx context.Context, tx txExecutor) error {
+		rs, err := tx.exec(ctx, fmt.Sprintf(`
+			SELECT owner, expires_at > CurrentUtcTimestamp() AS active
+			FROM %s WHERE id = $id;`, quote(m.lockTable)),
+			map[string]*Ydb.TypedValue{"$id": types.UTF8(lockID)}, false)
+		if err != nil {
+			return err
+		}
+
+		if len(rs.rows) > 0 {
+			var (
+				owner  string
+				active bool
+			)
+			if err = query.NewRow(rs.cols, rs.rows[0]).Scan(&owner, &active); err != nil {
+				return err //nolint:wrapcheck // unnecessary
+			}
+
+			if active && owner != m.owner {
+				return fmt.Errorf("%w: held by %s", ErrLocked, owner)
+			}
+		}
+
+		_, err = tx.exec(ctx, fmt.Sprintf(`
+			UPSERT INTO %s (id, owner, expires_at)
+			VALUES ($id, $owner, CurrentUtcTimestamp() + $ttl);`, quote(m.lockTable)),
+			map[string]*Ydb.TypedValue{
+				"$id":    types.UTF8(lockID),
+				"$owner": types.UTF8(m.owner),
+				"$ttl":   ttl,
+			}, true)
+
+		return err
+	})
+}
+
+// unlock releases lock if it is held by this migrator.
+// It is performed even if ctx is already canceled.
+func (m *Migrator) unlock(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+	defer cancel()
+
+	// Error is ignored, lock will be released after lease expiration.
+	_, _ = m.ex.exec(ctx, fmt.Sprintf(
+		`DELETE FROM %s WHERE id = $id AND owner = $owner;`, quote(m.lockTable)),
+		map[string]*Ydb.TypedValue{
+			"$id":    types.UTF8(lockID),
+			"$owner": types.UTF8(m.owner),
+		})
+}
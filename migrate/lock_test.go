@@ -0,0 +1,66 @@
+package migrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator_Lock(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	m := testMigrator(db, testMigrations())
+
+	require.NoError(t, m.lock(ctx))
+	require.NotNil(t, db.lock)
+	assert.Equal(t, "me", db.lock.owner)
+	assert.Equal(t, db.now.Add(time.Minute), db.lock.expiresAt)
+
+	// lease is renewed by owner
+	db.now = db.now.Add(30 * time.Second)
+	require.NoError(t, m.lock(ctx))
+	assert.Equal(t, db.now.Add(time.Minute), db.lock.expiresAt)
+	assert.Equal(t, 2, db.lockUpserts)
+
+	// active lease of another owner
+	other := testMigrator(db, testMigrations())
+	other.owner = "other"
+	require.ErrorIs(t, other.lock(ctx), ErrLocked)
+	assert.Equal(t, "me", db.lock.owner)
+
+	// lock of another owner is not released
+	other.unlock(ctx)
+	assert.Equal(t, "me", db.lock.owner)
+
+	// expired lease is stolen
+	db.now = db.now.Add(2 * time.Minute)
+	require.NoError(t, other.lock(ctx))
+	assert.Equal(t, "other", db.lock.owner)
+
+	m.unlock(ctx)
+	assert.Equal(t, "other", db.lock.owner)
+	other.unlock(ctx)
+	assert.Nil(t, db.lock)
+}
+
+func TestMigrator_Locked(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	db.lock = &fakeLock{owner: "other", expiresAt: db.now.Add(time.Second)}
+	m := testMigrator(db, testMigrations())
+
+	done, err := m.Up(ctx)
+	require.ErrorIs(t, err, ErrLocked)
+	assert.Empty(t, done)
+	assert.Empty(t, db.executed)
+	assert.Equal(t, "other", db.lock.owner)
+
+	db.now = db.now.Add(time.Second)
+	done, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, done, 3)
+	assert.Nil(t, db.lock)
+}
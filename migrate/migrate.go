@@ -0,0 +1,446 @@
+// Package migrate applies versioned YQL schema migrations.
+//
+// Migrations are read from fs.FS (including embed.FS) as pairs of
+// NNNN_name.up.yql and NNNN_name.down.yql files. Applied versions and their
+// checksums are stored in service table, concurrent runs are serialized
+// with lease-style lock stored in companion lock table.
+//
+// Migrations containing schema statements (CREATE, ALTER, DROP, ...) are
+// executed outside of transaction, since YDB does not support DDL in transactions.
+// Such migrations are not atomic: if migration fails midway, already executed
+// statements are not reverted. Other migrations are executed in serializable
+// transaction together with version record.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const (
+	defaultTable    = "schema_migrations"
+	defaultLockTTL  = 10 * time.Minute
+	lockTableSuffix = "_lock"
+)
+
+var (
+	ErrDrift     = errors.New("applied migrations differ from source")
+	ErrNoDown    = errors.New("migration cannot be rolled back")
+	ErrUnknown   = errors.New("applied migration is not found in source")
+	ErrVersion   = errors.New("unknown migration version")
+	ErrMigration = errors.New("migration failed")
+)
+
+type (
+	// Migrator applies migrations using query context.
+	Migrator struct {
+		ex executor
+
+		migrations []*Migration
+
+		table     string
+		lockTable string
+		owner     string
+		lockTTL   time.Duration
+	}
+
+	// Option configures Migrator.
+	Option func(*Migrator)
+
+	// Status is a state of particular migration version.
+	Status struct {
+		// AppliedAt is a time of migration apply, zero if migration is pending.
+		AppliedAt time.Time
+		// Name is a migration name.
+		Name string
+		// Checksum is a checksum of migration in source, empty if migration is missing.
+		Checksum string
+		// AppliedChecksum is a checksum recorded on apply, empty if migration is pending.
+		AppliedChecksum string
+		// Version is a migration version.
+		Version uint64
+		// Applied indicates that migration is applied.
+		Applied bool
+		// Missing indicates that migration is applied but is not present in source.
+		Missing bool
+	}
+
+	appliedRow struct {
+		AppliedAt time.Time `ydb:"applied_at"`
+		Name      string    `ydb:"name"`
+		Checksum  string    `ydb:"checksum"`
+		Version   uint64    `ydb:"version"`
+	}
+)
+
+// WithTable sets name of versions table, default is 'schema_migrations'.
+// Lock table has the same name with '_lock' suffix.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+		m.lockTable = table + lockTableSuffix
+	}
+}
+
+// WithLockTTL sets lock lease duration. Lease is extended before each migration,
+// so ttl should exceed duration of the longest migration. Default is 10 minutes.
+func WithLockTTL(ttl time.Duration) Option {
+	return func(m *Migrator) {
+		if ttl > 0 {
+			m.lockTTL = ttl
+		}
+	}
+}
+
+// WithOwner sets lock owner identifier. By default, it is generated
+// from hostname and process id.
+func WithOwner(owner string) Option {
+	return func(m *Migrator) {
+		m.owner = owner
+	}
+}
+
+// New creates migrator with migrations loaded from dir of fsys.
+func New(qc *query.Ctx, fsys fs.FS, dir string, opts ...Option) (*Migrator, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithMigrations(qc, migrations, opts...), nil
+}
+
+// NewWithMigrations creates migrator with provided migrations.
+// Migrations must be sorted by version.
+func NewWithMigrations(qc *query.Ctx, migrations []*Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		ex:         queryExecutor{qc: qc},
+		migrations: migrations,
+		table:      defaultTable,
+		lockTable:  defaultTable + lockTableSuffix,
+		lockTTL:    defaultLockTTL,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	if m.owner == "" {
+		m.owner = defaultOwner()
+	}
+
+	return m
+}
+
+// Migrations returns source migrations.
+func (m *Migrator) Migrations() []*Migration {
+	return m.migrations
+}
+
+// Init creates versions and lock tables if they do not exist.
+// It is called implicitly by Up, DownTo and Status.
+func (m *Migrator) Init(ctx context.Context) error {
+	_, err := m.ex.exec(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %[1]s (
+			version Uint64 NOT NULL,
+			name Utf8,
+			checksum Utf8,
+			applied_at Timestamp,
+			PRIMARY KEY (version)
+		);
+		CREATE TABLE IF NOT EXISTS %[2]s (
+			id Utf8 NOT NULL,
+			owner Utf8,
+			expires_at Timestamp,
+			PRIMARY KEY (id)
+		);`, quote(m.table), quote(m.lockTable)), nil)
+
+	return err
+}
+
+// Status returns state of all known migrations ordered by version.
+// It includes source migrations and applied migrations missing in source.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return status(m.migrations, applied), nil
+}
+
+// Drift checks that checksums of applied migrations match source
+// and all applied migrations are present in source.
+func (m *Migrator) Drift(ctx context.Context) error {
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	return drift(st)
+}
+
+// Up applies all pending migrations in version order.
+// It fails with ErrDrift if applied migrations differ from source.
+// Returned slice contains migrations applied during this call,
+// it can be non-empty even if error is returned.
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var done []*Migration
+
+	err := m.locked(ctx, func(ctx context.Context, st []Status) error {
+		if err := drift(st); err != nil {
+			return err
+		}
+
+		for _, s := range st {
+			if s.Applied {
+				continue
+			}
+
+			mig := m.migration(s.Version)
+			if err := m.up(ctx, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// DownTo rolls back applied migrations with version greater than target
+// in reverse version order. Zero version rolls back all migrations.
+// Returned slice contains migrations rolled back during this call,
+// it can be non-empty even if error is returned.
+func (m *Migrator) DownTo(ctx context.Context, version uint64) ([]*Migration, error) {
+	if version != 0 && m.migration(version) == nil {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, version)
+	}
+
+	var done []*Migration
+
+	err := m.locked(ctx, func(ctx context.Context, st []Status) error {
+		for i := len(st) - 1; i >= 0; i-- {
+			s := st[i]
+			if !s.Applied || s.Version <= version {
+				continue
+			}
+
+			if s.Missing {
+				return fmt.Errorf("%w: %d", ErrUnknown, s.Version)
+			}
+
+			mig := m.migration(s.Version)
+			if mig.Down == "" {
+				return fmt.Errorf("%w: %s", ErrNoDown, mig)
+			}
+
+			if err := m.down(ctx, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+func (m *Migrator) locked(ctx context.Context, op func(context.Context, []Status) error) error {
+	if err := m.Init(ctx); err != nil {
+		return err
+	}
+
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+
+	defer m.unlock(ctx)
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	return op(ctx, status(m.migrations, applied))
+}
+
+func (m *Migrator) up(ctx context.Context, mig *Migration) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+
+	record := fmt.Sprintf(`
+		UPSERT INTO %s (version, name, checksum, applied_at)
+		VALUES ($version, $name, $checksum, CurrentUtcTimestamp());`, quote(m.table))
+	params := map[string]*Ydb.TypedValue{
+		"$version":  types.Uint64(mig.Version),
+		"$name":     types.UTF8(mig.Name),
+		"$checksum": types.UTF8(mig.Checksum),
+	}
+
+	if err := m.apply(ctx, mig.Up, record, params); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrMigration, mig, err)
+	}
+
+	return nil
+}
+
+func (m *Migrator) down(ctx context.Context, mig *Migration) error {
+	if err := m.lock(ctx); err != nil {
+		return err
+	}
+
+	record := fmt.Sprintf(`DELETE FROM %s WHERE version = $version;`, quote(m.table))
+	params := map[string]*Ydb.TypedValue{
+		"$version": types.Uint64(mig.Version),
+	}
+
+	if err := m.apply(ctx, mig.Down, record, params); err != nil {
+		return fmt.Errorf("%w: %s (down): %w", ErrMigration, mig, err)
+	}
+
+	return nil
+}
+
+// apply executes migration content and then version record query.
+// Schema migrations are executed outside of transaction.
+func (m *Migrator) apply(ctx context.Context, content, record string, params map[string]*Ydb.TypedValue) error {
+	if isSchema(content) {
+		if _, err := m.ex.exec(ctx, content, nil); err != nil {
+			return err
+		}
+
+		_, err := m.ex.exec(ctx, record, params)
+
+		return err
+	}
+
+	return m.ex.tx(ctx, func(ctx context.Context, tx txExecutor) error {
+		if _, err := tx.exec(ctx, content, nil, false); err != nil {
+			return err
+		}
+
+		_, err := tx.exec(ctx, record, params, true)
+
+		return err
+	})
+}
+
+func (m *Migrator) applied(ctx context.Context) ([]appliedRow, error) {
+	rs, err := m.ex.exec(ctx, fmt.Sprintf(
+		`SELECT version, name, checksum, applied_at FROM %s ORDER BY version;`, quote(m.table)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]appliedRow, len(rs.rows))
+	for i, row := range rs.rows {
+		if err = query.NewRow(rs.cols, row).ScanStruct(&rows[i]); err != nil {
+			return nil, err //nolint:wrapcheck // unnecessary
+		}
+	}
+
+	return rows, nil
+}
+
+func (m *Migrator) migration(version uint64) *Migration {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return mig
+		}
+	}
+
+	return nil
+}
+
+func status(migrations []*Migration, applied []appliedRow) []Status {
+	st := make([]Status, 0, len(migrations)+len(applied))
+
+	i, j := 0, 0
+	for i < len(migrations) || j < len(applied) {
+		switch {
+		case j == len(applied) || (i < len(migrations) && migrations[i].Version < applied[j].Version):
+			st = append(st, Status{
+				Version:  migrations[i].Version,
+				Name:     migrations[i].Name,
+				Checksum: migrations[i].Checksum,
+			})
+			i++
+
+		case i == len(migrations) || applied[j].Version < migrations[i].Version:
+			st = append(st, Status{
+				Version:         applied[j].Version,
+				Name:            applied[j].Name,
+				AppliedChecksum: applied[j].Checksum,
+				AppliedAt:       applied[j].AppliedAt,
+				Applied:         true,
+				Missing:         true,
+			})
+			j++
+
+		default:
+			st = append(st, Status{
+				Version:         migrations[i].Version,
+				Name:            migrations[i].Name,
+				Checksum:        migrations[i].Checksum,
+				AppliedChecksum: applied[j].Checksum,
+				AppliedAt:       applied[j].AppliedAt,
+				Applied:         true,
+			})
+			i++
+			j++
+		}
+	}
+
+	return st
+}
+
+func drift(st []Status) error {
+	var errs []error
+
+	for _, s := range st {
+		switch {
+		case s.Missing:
+			errs = append(errs, fmt.Errorf("%04d_%s: %w", s.Version, s.Name, ErrUnknown))
+		case s.Drifted():
+			errs = append(errs, fmt.Errorf("%04d_%s: checksum %s, applied %s",
+				s.Version, s.Name, s.Checksum, s.AppliedChecksum))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errors.Join(append([]error{ErrDrift}, errs...)...)
+}
+
+// Drifted reports whether applied migration was changed in source after apply.
+func (s Status) Drifted() bool {
+	return s.Applied && !s.Missing && s.Checksum != s.AppliedChecksum
+}
+
+func quote(table string) string {
+	return "`" + table + "`"
+}
+
+func defaultOwner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s/%d/%d", host, os.Getpid(), time.Now().UnixNano())
+}
@@ -0,0 +1,240 @@
+package migrate
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_index.up.yql":  {Data: []byte("ALTER TABLE users ADD INDEX idx GLOBAL ON (name);")},
+		"migrations/0001_users.up.yql":      {Data: []byte("CREATE TABLE users (id Uint64, name Utf8, PRIMARY KEY (id));")},
+		"migrations/0001_users.down.yql":    {Data: []byte("DROP TABLE users;")},
+		"migrations/0010_seed.up.yql":       {Data: []byte("UPSERT INTO users (id, name) VALUES (1, 'admin');")},
+		"migrations/README.md":              {Data: []byte("readme")},
+		"migrations/nested/0003_x.up.yql":   {Data: []byte("SELECT 1;")},
+		"migrations/0004_bad_name.sideways": {Data: []byte("SELECT 1;")},
+	}
+
+	migrations, err := Load(fsys, "migrations")
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, uint64(1), migrations[0].Version)
+	assert.Equal(t, "users", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE users;", migrations[0].Down)
+	assert.Equal(t, Checksum(migrations[0].Up), migrations[0].Checksum)
+	assert.Equal(t, "0001_users", migrations[0].String())
+
+	assert.Equal(t, uint64(2), migrations[1].Version)
+	assert.Empty(t, migrations[1].Down)
+
+	assert.Equal(t, uint64(10), migrations[2].Version)
+	assert.Equal(t, "seed", migrations[2].Name)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	for name, fsys := range map[string]fstest.MapFS{
+		"duplicate version": {
+			"0001_a.up.yql": {Data: []byte("SELECT 1;")},
+			"01_b.up.yql":   {Data: []byte("SELECT 2;")},
+		},
+		"zero version": {
+			"0000_a.up.yql": {Data: []byte("SELECT 1;")},
+		},
+		"down without up": {
+			"0001_a.up.yql":   {Data: []byte("SELECT 1;")},
+			"0002_b.down.yql": {Data: []byte("SELECT 2;")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys, ".")
+			require.ErrorIs(t, err, ErrSource)
+		})
+	}
+
+	_, err := Load(fstest.MapFS{}, "missing")
+	require.ErrorIs(t, err, ErrSource)
+}
+
+func TestIsSchema(t *testing.T) {
+	for content, want := range map[string]bool{
+		"CREATE TABLE t (id Uint64, PRIMARY KEY (id));":               true,
+		"-- comment\n  alter table t add column x Utf8;":              true,
+		"PRAGMA TablePathPrefix = '/local';\nDROP TABLE t;":           true,
+		"UPSERT INTO t (id) VALUES (1);":                              false,
+		"UPDATE t SET created = 1 WHERE id = 2;":                      false,
+		"-- migrate:tx\nUPSERT INTO t (id) VALUES (1);\n-- DROP t":    false,
+		"-- migrate:notx\nUPSERT INTO t (id) VALUES (1);":             true,
+		"-- migrate:tx\nINSERT INTO t SELECT * FROM x;\nDROP TABLE y": false,
+	} {
+		assert.Equal(t, want, isSchema(content), content)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	now := time.Now()
+	migrations := []*Migration{
+		{Version: 1, Name: "a", Checksum: "c1"},
+		{Version: 2, Name: "b", Checksum: "c2"},
+		{Version: 4, Name: "d", Checksum: "c4"},
+	}
+	applied := []appliedRow{
+		{Version: 1, Name: "a", Checksum: "c1", AppliedAt: now},
+		{Version: 2, Name: "b", Checksum: "changed", AppliedAt: now},
+		{Version: 3, Name: "c", Checksum: "c3", AppliedAt: now},
+	}
+
+	st := status(migrations, applied)
+	require.Len(t, st, 4)
+
+	assert.Equal(t, Status{
+		Version: 1, Name: "a", Checksum: "c1", AppliedChecksum: "c1", AppliedAt: now, Applied: true,
+	}, st[0])
+	assert.False(t, st[0].Drifted())
+
+	assert.True(t, st[1].Drifted())
+
+	assert.Equal(t, uint64(3), st[2].Version)
+	assert.True(t, st[2].Missing)
+	assert.False(t, st[2].Drifted())
+
+	assert.Equal(t, uint64(4), st[3].Version)
+	assert.False(t, st[3].Applied)
+	assert.False(t, st[3].Drifted())
+
+	err := drift(st)
+	require.ErrorIs(t, err, ErrDrift)
+	require.ErrorIs(t, err, ErrUnknown)
+	assert.Contains(t, err.Error(), "0002_b: checksum c2, applied changed")
+
+	assert.NoError(t, drift(status(migrations, applied[:1])))
+}
+
+func testMigrations() []*Migration {
+	migrations := []*Migration{
+		{Version: 1, Name: "t", Up: "CREATE TABLE t (id Uint64, PRIMARY KEY (id));", Down: "DROP TABLE t;"},
+		{Version: 2, Name: "one", Up: "UPSERT INTO t (id) VALUES (1);", Down: "DELETE FROM t WHERE id = 1;"},
+		{Version: 3, Name: "two", Up: "UPSERT INTO t (id) VALUES (2);", Down: "DELETE FROM t WHERE id = 2;"},
+	}
+	for _, mig := range migrations {
+		mig.Checksum = Checksum(mig.Up)
+	}
+
+	return migrations
+}
+
+func testMigrator(db *fakeDB, migrations []*Migration) *Migrator {
+	m := NewWithMigrations(nil, migrations, WithOwner("me"), WithLockTTL(time.Minute))
+	m.ex = db
+
+	return m
+}
+
+func TestMigrator_Up(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	migrations := testMigrations()
+	m := testMigrator(db, migrations)
+
+	done, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrations, done)
+	assert.Equal(t, []string{migrations[0].Up, migrations[1].Up, migrations[2].Up}, db.executed)
+	assert.Len(t, db.versions, 3)
+	assert.Equal(t, migrations[1].Checksum, db.versions[2].Checksum)
+
+	// lock is acquired once and renewed before each migration, then released
+	assert.Equal(t, 4, db.lockUpserts)
+	assert.Nil(t, db.lock)
+
+	done, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, done)
+	assert.Len(t, db.executed, 3)
+
+	st, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, st, 3)
+	for _, s := range st {
+		assert.True(t, s.Applied)
+		assert.False(t, s.Drifted())
+	}
+
+	m.migrations[2].Checksum = "changed"
+	_, err = m.Up(ctx)
+	require.ErrorIs(t, err, ErrDrift)
+	require.ErrorIs(t, m.Drift(ctx), ErrDrift)
+}
+
+func TestMigrator_UpFailure(t *testing.T) {
+	db := newFakeDB()
+	db.failVersion = 2
+	migrations := testMigrations()
+
+	done, err := testMigrator(db, migrations).Up(context.Background())
+	require.ErrorIs(t, err, ErrMigration)
+	require.ErrorIs(t, err, errFake)
+	assert.Equal(t, migrations[:1], done)
+
+	// transaction of failed migration is rolled back, next migrations are not applied
+	assert.Equal(t, []string{migrations[0].Up}, db.executed)
+	assert.Equal(t, []uint64{1}, slices.Sorted(maps.Keys(db.versions)))
+	assert.Nil(t, db.lock)
+}
+
+func TestMigrator_DownTo(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	migrations := testMigrations()
+	m := testMigrator(db, migrations)
+
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+	db.executed = nil
+
+	_, err = m.DownTo(ctx, 5)
+	require.ErrorIs(t, err, ErrVersion)
+
+	done, err := m.DownTo(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []*Migration{migrations[2], migrations[1]}, done)
+	assert.Equal(t, []string{migrations[2].Down, migrations[1].Down}, db.executed)
+	assert.Equal(t, []uint64{1}, slices.Sorted(maps.Keys(db.versions)))
+	assert.Nil(t, db.lock)
+
+	done, err = m.DownTo(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, migrations[:1], done)
+	assert.Empty(t, db.versions)
+}
+
+func TestMigrator_DownToErrors(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	migrations := testMigrations()
+	migrations[1].Down = ""
+	m := testMigrator(db, migrations)
+
+	_, err := m.Up(ctx)
+	require.NoError(t, err)
+
+	// rollback stops at migration without down
+	done, err := m.DownTo(ctx, 0)
+	require.ErrorIs(t, err, ErrNoDown)
+	assert.Equal(t, migrations[2:], done)
+	assert.Equal(t, []uint64{1, 2}, slices.Sorted(maps.Keys(db.versions)))
+
+	// applied migration which is missing in source
+	db.versions[7] = appliedRow{Version: 7, Name: "x"}
+	_, err = m.DownTo(ctx, 1)
+	require.ErrorIs(t, err, ErrUnknown)
+	assert.Nil(t, db.lock)
+}
@@ -0,0 +1,152 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	directiveTx   = "-- migrate:tx"
+	directiveNoTx = "-- migrate:notx"
+)
+
+var (
+	ErrSource = errors.New("invalid migrations source")
+
+	fileNameRe = regexp.MustCompile(`^(\d+)_([^.]+)\.(up|down)\.yql$`)
+
+	// schemaStmtRe matches statements which cannot be executed inside transaction.
+	schemaStmtRe = regexp.MustCompile(`(?im)^\s*(CREATE|ALTER|DROP|GRANT|REVOKE)\s`)
+)
+
+type (
+	// Migration is a single versioned schema change.
+	Migration struct {
+		// Name is a human-readable part of file name.
+		Name string
+		// Up is a content of NNNN_name.up.yql file.
+		Up string
+		// Down is a content of NNNN_name.down.yql file, it can be empty
+		// if migration cannot be rolled back.
+		Down string
+		// Checksum is a hex-encoded sha256 of Up content.
+		Checksum string
+		// Version is a numeric prefix of file name.
+		Version uint64
+	}
+)
+
+// Load reads migrations from dir of fsys. Migration files must be named
+// as NNNN_name.up.yql and NNNN_name.down.yql, where NNNN is a version number.
+// Files which do not match this pattern are ignored. Down file is optional.
+//
+// Returned migrations are sorted by version.
+func Load(fsys fs.FS, dir string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.Join(ErrSource, err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	downs := make(map[uint64]string)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNameRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, errV := strconv.ParseUint(match[1], 10, 64)
+		if errV != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s: bad version", ErrSource, entry.Name())
+		}
+
+		content, errR := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if errR != nil {
+			return nil, errors.Join(ErrSource, errR)
+		}
+
+		if match[3] == "down" {
+			if _, ok := downs[version]; ok {
+				return nil, fmt.Errorf("%w: duplicate down migration %d", ErrSource, version)
+			}
+			downs[version] = string(content)
+
+			continue
+		}
+
+		if m, ok := byVersion[version]; ok {
+			return nil, fmt.Errorf("%w: duplicate migration %d: %s and %s", ErrSource, version, m.Name, match[2])
+		}
+
+		byVersion[version] = &Migration{
+			Version:  version,
+			Name:     match[2],
+			Up:       string(content),
+			Checksum: Checksum(string(content)),
+		}
+	}
+
+	for version, down := range downs {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: down migration %d has no up migration", ErrSource, version)
+		}
+		m.Down = down
+	}
+
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, m)
+	}
+
+	slices.SortFunc(migrations, func(a, b *Migration) int {
+		switch {
+		case a.Version < b.Version:
+			return -1
+		case a.Version > b.Version:
+			return 1
+		}
+		return 0
+	})
+
+	return migrations, nil
+}
+
+// Checksum calculates migration checksum of content.
+func Checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns migration identifier like 0001_name.
+func (m *Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// isSchema reports whether content must be executed outside of transaction.
+// Explicit first line directive '-- migrate:tx' or '-- migrate:notx'
+// takes precedence over detection of schema statements.
+func isSchema(content string) bool {
+	firstLine, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	switch strings.ToLower(strings.TrimSpace(firstLine)) {
+	case directiveTx:
+		return false
+	case directiveNoTx:
+		return true
+	}
+
+	return schemaStmtRe.MatchString(content)
+}
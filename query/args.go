@@ -0,0 +1,126 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+)
+
+const (
+	// SyntaxYQL is YQL syntax. It is used by default.
+	SyntaxYQL = Ydb_Query.Syntax_SYNTAX_YQL_V1
+	// SyntaxPG is PostgreSQL syntax. Positional parameters $1, $2, ...
+	// are passed to YDB as $p1, $p2, ...
+	SyntaxPG = Ydb_Query.Syntax_SYNTAX_PG
+)
+
+var (
+	ErrArgs = errors.New("cannot convert query argument")
+)
+
+type (
+	// goArgs holds Go values of query arguments,
+	// they are converted to parameters when query is rendered.
+	goArgs struct {
+		named      map[string]any
+		structs    []any
+		positional []any
+	}
+)
+
+func (a *goArgs) empty() bool {
+	return len(a.named) == 0 && len(a.structs) == 0 && len(a.positional) == 0
+}
+
+func (a *goArgs) set(name string, val any) {
+	if a.named == nil {
+		a.named = make(map[string]any)
+	}
+	if !strings.HasPrefix(name, "$") {
+		name = "$" + name
+	}
+	a.named[name] = val
+}
+
+// withArgs returns params with added args. Struct fields are added first, then named args
+// and then positional args as $p1, $p2, ... Args are converted to YQL types
+// or to PostgreSQL types if syntax is SyntaxPG. Provided params are not modified.
+func withArgs(
+	params map[string]*Ydb.TypedValue,
+	args *goArgs,
+	syntax Ydb_Query.Syntax,
+) (map[string]*Ydb.TypedValue, error) {
+	if args.empty() {
+		return params, nil
+	}
+
+	convert := types.FromGo
+	if syntax == SyntaxPG {
+		convert = types.PgFromGo
+	}
+
+	merged := make(map[string]*Ydb.TypedValue, len(params)+len(args.named)+len(args.positional))
+	maps.Copy(merged, params)
+
+	for _, v := range args.structs {
+		if syntax == SyntaxPG {
+			return nil, fmt.Errorf("%w: struct args are not supported with PostgreSQL syntax", ErrArgs)
+		}
+		fields, err := types.ParamsFromStruct(v)
+		if err != nil {
+			return nil, errors.Join(ErrArgs, err)
+		}
+		maps.Copy(merged, fields)
+	}
+
+	for name, arg := range args.named {
+		val, err := convert(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrArgs, name, err)
+		}
+		merged[name] = val
+	}
+
+	for i, arg := range args.positional {
+		name := fmt.Sprintf("$p%d", i+1)
+		val, err := convert(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrArgs, name, err)
+		}
+		merged[name] = val
+	}
+
+	return merged, nil
+}
+
+// render returns query content and parameters to be sent to YDB.
+// DECLARE statements are generated only for YQL syntax.
+func render(
+	content string,
+	params map[string]*Ydb.TypedValue,
+	args *goArgs,
+	syntax Ydb_Query.Syntax,
+	autoDeclare bool,
+) (string, map[string]*Ydb.TypedValue, error) {
+	params, err := withArgs(params, args, syntax)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !autoDeclare || syntax == SyntaxPG {
+		return content, params, nil
+	}
+
+	content, err = withDeclares(content, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return content, params, nil
+}
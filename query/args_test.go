@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestQuery_Args(t *testing.T) {
+	qc := (&Ctx{}).AutoDeclare()
+
+	params := map[string]*Ydb.TypedValue{"$name": types.UTF8("test")}
+	q := qc.Query("SELECT $p1, $p2, $name;").Params(params).Args(int32(1), []byte("qwe"))
+
+	content, got, err := q.render()
+	require.NoError(t, err)
+	assert.Equal(t, "DECLARE $name AS Utf8;\nDECLARE $p1 AS Int32;\nDECLARE $p2 AS String;\nSELECT $p1, $p2, $name;", content)
+	assert.Len(t, got, 3)
+	assert.Equal(t, types.Int32(1), got["$p1"])
+	assert.Len(t, params, 1, "provided params must not be modified")
+
+	_, _, err = q.Args(make(chan int)).render()
+	require.ErrorIs(t, err, ErrArgs)
+	require.ErrorIs(t, err, types.ErrGoType)
+}
+
+func TestQuery_ArgsPG(t *testing.T) {
+	qc := (&Ctx{}).AutoDeclare().WithSyntax(SyntaxPG)
+
+	q := qc.Query("SELECT $1, $2;").Args(int64(10), "test")
+	assert.Equal(t, SyntaxPG, q.syntax)
+
+	content, params, err := q.render()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT $1, $2;", content, "DECLARE is not supported in PG syntax")
+	assert.Equal(t, types.Pg(types.PgInt8, "10"), params["$p1"])
+	assert.Equal(t, types.Pg(types.PgText, "test"), params["$p2"])
+
+	_, params, err = q.Syntax(SyntaxYQL).render()
+	require.NoError(t, err)
+	assert.Equal(t, types.Int64(10), params["$p1"])
+}
+
+func TestQuery_ArgNamed(t *testing.T) {
+	type filter struct {
+		Since time.Time `ydb:"since,type=Datetime"`
+		Tags  []string
+	}
+
+	since := time.Unix(1726836887, 0)
+	q := (&Ctx{}).AutoDeclare().Query("SELECT $since, $tags, $limit, $id;").
+		ArgsFromStruct(filter{Since: since, Tags: []string{"a"}}).
+		Arg("limit", uint64(10)).
+		Arg("$id", (*int64)(nil))
+
+	content, params, err := q.render()
+	require.NoError(t, err)
+	assert.Equal(t, "DECLARE $id AS Optional<Int64>;\nDECLARE $limit AS Uint64;\n"+
+		"DECLARE $since AS Datetime;\nDECLARE $tags AS List<Utf8>;\nSELECT $since, $tags, $limit, $id;", content)
+	assert.Equal(t, uint32(1726836887), params["$since"].Value.GetUint32Value())
+
+	_, _, err = q.Syntax(SyntaxPG).render()
+	require.ErrorIs(t, err, ErrArgs)
+
+	_, _, err = (&Ctx{}).Query("SELECT 1;").ArgsFromStruct(1).render()
+	require.ErrorIs(t, err, ErrArgs)
+}
@@ -0,0 +1,150 @@
+package arrow
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sync/atomic"
+
+	"github.com/adwski/ydb-go-query/query"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+var (
+	ErrResultSet = errors.New("part of another result set received")
+)
+
+var _ array.RecordReader = (*RecordReader)(nil)
+
+type (
+	// PartReader provides result parts, it is implemented by query.ResultStream.
+	PartReader interface {
+		Parts() iter.Seq2[*query.ResultPart, error]
+	}
+
+	// RecordReader reads Arrow records from result stream, one record per result part.
+	// Parts are requested from stream only when previous record is consumed.
+	// All parts must belong to the same result set.
+	//
+	// Reader does not close stream, it should be closed by caller
+	// if it was not read till the end.
+	RecordReader struct {
+		conv    *Converter
+		pending *query.ResultPart
+		next    func() (*query.ResultPart, error, bool)
+		stop    func()
+		rec     arrow.Record
+		err     error
+		index   int64
+		refs    atomic.Int64
+	}
+)
+
+// NewRecordReader creates RecordReader over result parts. First part is received
+// to determine schema. If mem is nil, memory.DefaultAllocator is used.
+func NewRecordReader(mem memory.Allocator, parts PartReader) (*RecordReader, error) {
+	next, stop := iter.Pull2(parts.Parts())
+
+	part, err, ok := next()
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	r := &RecordReader{
+		next: next,
+		stop: stop,
+	}
+	r.refs.Store(1)
+	if ok {
+		r.pending, r.index = part, part.Index
+		r.conv, err = NewConverter(mem, part.Cols)
+	} else {
+		// empty result
+		r.conv, err = NewConverter(mem, nil)
+	}
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Schema returns Arrow schema of result set.
+func (r *RecordReader) Schema() *arrow.Schema {
+	return r.conv.Schema()
+}
+
+// Next converts next non-empty result part to record. It returns false
+// when stream is finished or error occurs. Record returned by previous call is released.
+func (r *RecordReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+
+	for r.err == nil {
+		part := r.pending
+		r.pending = nil
+		if part == nil {
+			var (
+				err error
+				ok  bool
+			)
+			if part, err, ok = r.next(); err != nil {
+				r.err = err
+				break
+			} else if !ok {
+				break
+			}
+		}
+
+		if part.Index != r.index {
+			r.err = fmt.Errorf("%w: index %d, expected %d", ErrResultSet, part.Index, r.index)
+			break
+		}
+		if len(part.ResultSet.GetRows()) == 0 {
+			continue
+		}
+
+		r.rec, r.err = r.conv.Record(part.ResultSet.GetRows())
+
+		return r.err == nil
+	}
+
+	return false
+}
+
+// Record returns current record. It is valid until next call to Next.
+func (r *RecordReader) Record() arrow.Record {
+	return r.rec
+}
+
+// Err returns error which stopped reading.
+func (r *RecordReader) Err() error {
+	return r.err
+}
+
+// Retain increases reference count of reader.
+func (r *RecordReader) Retain() {
+	r.refs.Add(1)
+}
+
+// Release decreases reference count of reader. When it reaches zero,
+// current record and builders are released.
+func (r *RecordReader) Release() {
+	if r.refs.Add(-1) != 0 {
+		return
+	}
+
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+	r.conv.Release()
+	r.stop()
+}
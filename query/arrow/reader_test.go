@@ -0,0 +1,108 @@
+package arrow
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/adwski/ydb-go-query/query"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+type fakeParts struct {
+	parts []*query.ResultPart
+	err   error
+	recvd int
+}
+
+func (fp *fakeParts) Parts() iter.Seq2[*query.ResultPart, error] {
+	return func(yield func(*query.ResultPart, error) bool) {
+		for len(fp.parts) > 0 {
+			part := fp.parts[0]
+			fp.parts = fp.parts[1:]
+			fp.recvd++
+			if !yield(part, nil) {
+				return
+			}
+		}
+		if fp.err != nil {
+			yield(nil, fp.err)
+		}
+	}
+}
+
+func testParts(t *testing.T) []*query.ResultPart {
+	t.Helper()
+
+	set := testResultSet(t)
+
+	return []*query.ResultPart{
+		{ResultSet: set, Cols: set.Columns},
+		{ResultSet: &Ydb.ResultSet{}, Cols: set.Columns},
+		{ResultSet: &Ydb.ResultSet{Rows: set.Rows[:1]}, Cols: set.Columns},
+	}
+}
+
+func TestRecordReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fp := &fakeParts{parts: testParts(t)}
+	r, err := NewRecordReader(mem, fp)
+	require.NoError(t, err)
+	defer r.Release()
+
+	assert.Equal(t, 7, r.Schema().NumFields())
+	assert.Equal(t, 1, fp.recvd)
+
+	var rows []int64
+	for r.Next() {
+		rows = append(rows, r.Record().NumRows())
+		if len(rows) == 1 {
+			// parts are received on demand
+			assert.Equal(t, 1, fp.recvd)
+		}
+	}
+	require.NoError(t, r.Err())
+	assert.Equal(t, []int64{2, 1}, rows)
+
+	var _ array.RecordReader = r
+}
+
+func TestRecordReader_Errors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	streamErr := errors.New("stream error")
+	parts := testParts(t)
+	parts[2].Index = 1
+	for _, tt := range []struct {
+		fp   *fakeParts
+		want error
+	}{
+		{fp: &fakeParts{parts: parts}, want: ErrResultSet},
+		{fp: &fakeParts{parts: testParts(t)[:1], err: streamErr}, want: streamErr},
+	} {
+		r, err := NewRecordReader(mem, tt.fp)
+		require.NoError(t, err)
+		require.True(t, r.Next())
+		require.False(t, r.Next())
+		require.ErrorIs(t, r.Err(), tt.want)
+		r.Release()
+	}
+
+	_, err := NewRecordReader(mem, &fakeParts{err: streamErr})
+	require.ErrorIs(t, err, streamErr)
+
+	r, err := NewRecordReader(mem, &fakeParts{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, r.Schema().NumFields())
+	assert.False(t, r.Next())
+	require.NoError(t, r.Err())
+	r.Release()
+}
@@ -0,0 +1,89 @@
+package arrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// Converter builds Arrow records from rows of result set parts.
+// Builders are reused between records, so Converter should be created
+// once per result set. It is not safe for concurrent use.
+type Converter struct {
+	mem     memory.Allocator
+	schema  *arrow.Schema
+	builder *array.RecordBuilder
+	fields  []field
+}
+
+// NewConverter creates Converter for result set with provided columns.
+// If mem is nil, memory.DefaultAllocator is used. See Schema for type mapping.
+func NewConverter(mem memory.Allocator, cols []*Ydb.Column) (*Converter, error) {
+	schema, fields, err := schemaOf(cols)
+	if err != nil {
+		return nil, err
+	}
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+
+	return &Converter{
+		mem:     mem,
+		schema:  schema,
+		fields:  fields,
+		builder: array.NewRecordBuilder(mem, schema),
+	}, nil
+}
+
+// Schema returns Arrow schema of result set.
+func (c *Converter) Schema() *arrow.Schema {
+	return c.schema
+}
+
+// Record converts rows to Arrow record, it must be released by caller.
+func (c *Converter) Record(rows []*Ydb.Value) (arrow.Record, error) {
+	c.builder.Reserve(len(rows))
+	for rIdx, row := range rows {
+		items := row.GetItems()
+		if len(items) != len(c.fields) {
+			c.reset()
+			return nil, fmt.Errorf("%w: row %d: %d values, %d columns", ErrValue, rIdx, len(items), len(c.fields))
+		}
+		for i, item := range items {
+			if err := c.fields[i].append(c.builder.Field(i), item); err != nil {
+				c.reset()
+				return nil, fmt.Errorf("row %d: column %q: %w", rIdx, c.schema.Field(i).Name, err)
+			}
+		}
+	}
+
+	return c.builder.NewRecord(), nil
+}
+
+// Release releases builders of Converter.
+func (c *Converter) Release() {
+	c.builder.Release()
+}
+
+// reset drops partially built record. Nested builders may have
+// inconsistent lengths at this point, so they are recreated.
+func (c *Converter) reset() {
+	c.builder.Release()
+	c.builder = array.NewRecordBuilder(c.mem, c.schema)
+}
+
+// FromResultSet converts result set or its part to Arrow record, it must be released by caller.
+// Columns are taken from result set, so it should be the first part of result set.
+// Use Converter to convert subsequent parts.
+func FromResultSet(mem memory.Allocator, set *Ydb.ResultSet) (arrow.Record, error) {
+	conv, err := NewConverter(mem, set.GetColumns())
+	if err != nil {
+		return nil, err
+	}
+	defer conv.Release()
+
+	return conv.Record(set.GetRows())
+}
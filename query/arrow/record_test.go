@@ -0,0 +1,106 @@
+package arrow
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func testResultSet(t *testing.T) *Ydb.ResultSet {
+	t.Helper()
+
+	ts := time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC)
+	created, err := types.Timestamp(ts)
+	require.NoError(t, err)
+	amount, err := types.Decimal(big.NewInt(-1500), 22, 2)
+	require.NoError(t, err)
+	tags, err := types.List(types.Optional(types.UTF8("a")), types.NullOf(types.UTF8("").Type))
+	require.NoError(t, err)
+	attrs, err := types.Dict(types.Pair(types.UTF8("k"), types.Int64(7)))
+	require.NoError(t, err)
+	name := types.Optional(types.UTF8("alice"))
+
+	row := func(id uint64, name *Ydb.TypedValue) *Ydb.Value {
+		return &Ydb.Value{Items: []*Ydb.Value{
+			types.Uint64(id).Value, name.Value, amount.Value, created.Value, tags.Value, attrs.Value,
+			types.Struct(types.Field("x", types.Int32(1))).Value,
+		}}
+	}
+
+	return &Ydb.ResultSet{
+		Columns: []*Ydb.Column{
+			{Name: "id", Type: types.Uint64(0).Type},
+			{Name: "name", Type: name.Type},
+			{Name: "amount", Type: amount.Type},
+			{Name: "created", Type: created.Type},
+			{Name: "tags", Type: tags.Type},
+			{Name: "attrs", Type: attrs.Type},
+			{Name: "point", Type: types.Struct(types.Field("x", types.Int32(1))).Type},
+		},
+		Rows: []*Ydb.Value{row(1, name), row(2, types.NullOf(types.UTF8("").Type))},
+	}
+}
+
+func TestFromResultSet(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec, err := FromResultSet(mem, testResultSet(t))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	require.Equal(t, int64(2), rec.NumRows())
+	assert.Equal(t, []uint64{1, 2}, rec.Column(0).(*array.Uint64).Uint64Values())
+
+	names := rec.Column(1).(*array.String)
+	assert.Equal(t, "alice", names.Value(0))
+	assert.True(t, names.IsNull(1))
+
+	amounts := rec.Column(2).(*array.Decimal128)
+	assert.Equal(t, decimal128.FromI64(-1500), amounts.Value(0))
+
+	created := rec.Column(3).(*array.Timestamp)
+	toTime, err := created.DataType().(*arrow.TimestampType).GetToTimeFunc()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC), toTime(created.Value(0)))
+
+	tags := rec.Column(4).(*array.List)
+	assert.Equal(t, `["a",null]`, tags.ValueStr(0))
+	assert.Equal(t, `[{"key":"k","value":7}]`, rec.Column(5).(*array.Map).ValueStr(0))
+	assert.Equal(t, `{"x":1}`, rec.Column(6).(*array.Struct).ValueStr(1))
+}
+
+func TestConverter_Error(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	set := testResultSet(t)
+	conv, err := NewConverter(mem, set.Columns)
+	require.NoError(t, err)
+	defer conv.Release()
+
+	bad := testResultSet(t).Rows[1]
+	bad.Items[4].Items[0] = types.Uint64(1).Value
+	_, err = conv.Record([]*Ydb.Value{set.Rows[0], bad})
+	require.ErrorIs(t, err, ErrValue)
+	assert.Contains(t, err.Error(), `row 1: column "tags": [0]: unexpected value: expected Utf8`)
+
+	_, err = conv.Record([]*Ydb.Value{{Items: bad.Items[:1]}})
+	require.ErrorIs(t, err, ErrValue)
+
+	// converter is usable after error
+	rec, err := conv.Record(set.Rows)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), rec.NumRows())
+	rec.Release()
+}
@@ -0,0 +1,439 @@
+// Package arrow converts query results to Apache Arrow records.
+//
+// It is a separate module, so Arrow dependencies are not required
+// by users of ydb-go-query who do not need them.
+package arrow
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrUnsupported = errors.New("type is not supported")
+	ErrValue       = errors.New("unexpected value")
+)
+
+type (
+	// appendFunc appends YDB value to builder of corresponding Arrow type.
+	appendFunc func(b array.Builder, val *Ydb.Value) error
+
+	// field holds Arrow type of YDB type and func which appends its values.
+	field struct {
+		typ      arrow.DataType
+		append   appendFunc
+		nullable bool
+	}
+)
+
+// Schema returns Arrow schema of result set columns:
+//   - Optional types are nullable fields of their item types
+//   - Bool, IntN, UintN, Float and Double are mapped to corresponding Arrow types
+//   - Utf8, Json, JsonDocument, DyNumber and Tz types to String, String and Yson to Binary
+//   - Uuid to FixedSizeBinary(16) in canonical byte order
+//   - Decimal to Decimal128 with the same precision and scale
+//   - Date and Date32 to Date32, Datetime and Datetime64 to Timestamp(s, UTC),
+//     Timestamp and Timestamp64 to Timestamp(us, UTC), Interval and Interval64 to Duration(us)
+//   - List to List, Struct to Struct, Tuple to Struct with fields named by indexes, Dict to Map
+//   - Tagged to its type, Void and Null to Null
+//
+// Variant, EmptyList, EmptyDict and PostgreSQL types are not supported.
+func Schema(cols []*Ydb.Column) (*arrow.Schema, error) {
+	schema, _, err := schemaOf(cols)
+
+	return schema, err
+}
+
+func schemaOf(cols []*Ydb.Column) (*arrow.Schema, []field, error) {
+	var (
+		fields       = make([]field, len(cols))
+		schemaFields = make([]arrow.Field, len(cols))
+	)
+	for i, col := range cols {
+		f, err := fieldOf(col.GetType())
+		if err != nil {
+			return nil, nil, fmt.Errorf("column %q: %w", col.GetName(), err)
+		}
+		fields[i] = f
+		schemaFields[i] = arrow.Field{Name: col.GetName(), Type: f.typ, Nullable: f.nullable}
+	}
+
+	return arrow.NewSchema(schemaFields, nil), fields, nil
+}
+
+//nolint:cyclop,gocognit,funlen // flat switch over type kinds
+func fieldOf(typ *Ydb.Type) (field, error) {
+	switch t := typ.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		return primitiveField(typ, t.TypeId)
+	case *Ydb.Type_DecimalType:
+		return field{
+			typ: &arrow.Decimal128Type{
+				Precision: int32(t.DecimalType.GetPrecision()), //nolint:gosec // precision is at most 35
+				Scale:     int32(t.DecimalType.GetScale()),     //nolint:gosec // scale is at most precision
+			},
+			append: func(b array.Builder, val *Ydb.Value) error {
+				lo, ok := val.GetValue().(*Ydb.Value_Low_128)
+				if !ok {
+					return mismatch(typ)
+				}
+				b.(*array.Decimal128Builder).Append(decimal128.New(int64(val.GetHigh_128()), lo.Low_128)) //nolint:gosec // two's complement
+
+				return nil
+			},
+		}, nil
+	case *Ydb.Type_OptionalType:
+		itemType := t.OptionalType.GetItem()
+		item, err := fieldOf(itemType)
+		if err != nil {
+			return field{}, err
+		}
+		_, nested := itemType.GetType().(*Ydb.Type_OptionalType)
+
+		return field{
+			typ:      item.typ,
+			nullable: true,
+			append: func(b array.Builder, val *Ydb.Value) error {
+				switch v := val.GetValue().(type) {
+				case *Ydb.Value_NullFlagValue:
+					b.AppendNull()
+					return nil
+				case *Ydb.Value_NestedValue:
+					if nested {
+						val = v.NestedValue
+					}
+				}
+
+				return item.append(b, val)
+			},
+		}, nil
+	case *Ydb.Type_ListType:
+		item, err := fieldOf(t.ListType.GetItem())
+		if err != nil {
+			return field{}, err
+		}
+
+		return field{
+			typ: arrow.ListOfField(arrow.Field{Name: "item", Type: item.typ, Nullable: item.nullable}),
+			append: func(b array.Builder, val *Ydb.Value) error {
+				lb := b.(*array.ListBuilder)
+				lb.Append(true)
+				vb := lb.ValueBuilder()
+				for i, v := range val.GetItems() {
+					if err := item.append(vb, v); err != nil {
+						return types.WithPath(err, "["+strconv.Itoa(i)+"]")
+					}
+				}
+
+				return nil
+			},
+		}, nil
+	case *Ydb.Type_StructType:
+		members := t.StructType.GetMembers()
+		names := make([]string, len(members))
+		memberTypes := make([]*Ydb.Type, len(members))
+		for i, m := range members {
+			names[i], memberTypes[i] = m.GetName(), m.GetType()
+		}
+
+		return structField(typ, names, memberTypes)
+	case *Ydb.Type_TupleType:
+		elements := t.TupleType.GetElements()
+		names := make([]string, len(elements))
+		for i := range elements {
+			names[i] = strconv.Itoa(i)
+		}
+
+		return structField(typ, names, elements)
+	case *Ydb.Type_DictType:
+		return dictField(t.DictType)
+	case *Ydb.Type_TaggedType:
+		return fieldOf(t.TaggedType.GetType())
+	case *Ydb.Type_VoidType, *Ydb.Type_NullType:
+		return field{
+			typ:      arrow.Null,
+			nullable: true,
+			append: func(b array.Builder, _ *Ydb.Value) error {
+				b.AppendNull()
+				return nil
+			},
+		}, nil
+	default:
+		return field{}, fmt.Errorf("%w: %s", ErrUnsupported, types.FormatType(typ))
+	}
+}
+
+// structField returns Struct field with members of provided names and types.
+// Error paths of tuple elements are their indexes.
+func structField(typ *Ydb.Type, names []string, memberTypes []*Ydb.Type) (field, error) {
+	var (
+		members      = make([]field, len(memberTypes))
+		arrowMembers = make([]arrow.Field, len(memberTypes))
+		segments     = make([]string, len(memberTypes))
+	)
+	for i, mt := range memberTypes {
+		segments[i] = "." + names[i]
+		if typ.GetTupleType() != nil {
+			segments[i] = "[" + names[i] + "]"
+		}
+		m, err := fieldOf(mt)
+		if err != nil {
+			return field{}, types.WithPath(err, segments[i])
+		}
+		members[i] = m
+		arrowMembers[i] = arrow.Field{Name: names[i], Type: m.typ, Nullable: m.nullable}
+	}
+
+	return field{
+		typ: arrow.StructOf(arrowMembers...),
+		append: func(b array.Builder, val *Ydb.Value) error {
+			items := val.GetItems()
+			if len(items) != len(members) {
+				return fmt.Errorf("%w: %d items, expected %s", ErrValue, len(items), types.FormatType(typ))
+			}
+			sb := b.(*array.StructBuilder)
+			sb.Append(true)
+			for i, item := range items {
+				if err := members[i].append(sb.FieldBuilder(i), item); err != nil {
+					return types.WithPath(err, segments[i])
+				}
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+func dictField(dt *Ydb.DictType) (field, error) {
+	key, err := fieldOf(dt.GetKey())
+	if err != nil {
+		return field{}, err
+	}
+	if key.nullable {
+		return field{}, fmt.Errorf("%w: nullable dict key %s", ErrUnsupported, types.FormatType(dt.GetKey()))
+	}
+	payload, err := fieldOf(dt.GetPayload())
+	if err != nil {
+		return field{}, err
+	}
+
+	return field{
+		typ: arrow.MapOf(key.typ, payload.typ),
+		append: func(b array.Builder, val *Ydb.Value) error {
+			mb := b.(*array.MapBuilder)
+			mb.Append(true)
+			kb, ib := mb.KeyBuilder(), mb.ItemBuilder()
+			for i, pair := range val.GetPairs() {
+				segment := "[" + strconv.Itoa(i) + "]"
+				if err := key.append(kb, pair.GetKey()); err != nil {
+					return types.WithPath(err, segment+".key")
+				}
+				if err := payload.append(ib, pair.GetPayload()); err != nil {
+					return types.WithPath(err, segment)
+				}
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+//nolint:cyclop,funlen // flat switch over primitive types
+func primitiveField(typ *Ydb.Type, id Ydb.Type_PrimitiveTypeId) (field, error) {
+	var (
+		dt  arrow.DataType
+		app func(b array.Builder, val *Ydb.Value) bool
+	)
+	switch id { //nolint:exhaustive // unsupported types are handled by default
+	case Ydb.Type_BOOL:
+		dt, app = arrow.FixedWidthTypes.Boolean, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_BoolValue)
+			if ok {
+				b.(*array.BooleanBuilder).Append(v.BoolValue)
+			}
+			return ok
+		}
+	case Ydb.Type_INT8:
+		dt, app = arrow.PrimitiveTypes.Int8, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int32Value)
+			if ok {
+				b.(*array.Int8Builder).Append(int8(v.Int32Value)) //nolint:gosec // value of Int8 type
+			}
+			return ok
+		}
+	case Ydb.Type_INT16:
+		dt, app = arrow.PrimitiveTypes.Int16, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int32Value)
+			if ok {
+				b.(*array.Int16Builder).Append(int16(v.Int32Value)) //nolint:gosec // value of Int16 type
+			}
+			return ok
+		}
+	case Ydb.Type_INT32:
+		dt, app = arrow.PrimitiveTypes.Int32, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int32Value)
+			if ok {
+				b.(*array.Int32Builder).Append(v.Int32Value)
+			}
+			return ok
+		}
+	case Ydb.Type_INT64:
+		dt, app = arrow.PrimitiveTypes.Int64, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int64Value)
+			if ok {
+				b.(*array.Int64Builder).Append(v.Int64Value)
+			}
+			return ok
+		}
+	case Ydb.Type_UINT8:
+		dt, app = arrow.PrimitiveTypes.Uint8, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint32Value)
+			if ok {
+				b.(*array.Uint8Builder).Append(uint8(v.Uint32Value)) //nolint:gosec // value of Uint8 type
+			}
+			return ok
+		}
+	case Ydb.Type_UINT16:
+		dt, app = arrow.PrimitiveTypes.Uint16, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint32Value)
+			if ok {
+				b.(*array.Uint16Builder).Append(uint16(v.Uint32Value)) //nolint:gosec // value of Uint16 type
+			}
+			return ok
+		}
+	case Ydb.Type_UINT32:
+		dt, app = arrow.PrimitiveTypes.Uint32, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint32Value)
+			if ok {
+				b.(*array.Uint32Builder).Append(v.Uint32Value)
+			}
+			return ok
+		}
+	case Ydb.Type_UINT64:
+		dt, app = arrow.PrimitiveTypes.Uint64, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint64Value)
+			if ok {
+				b.(*array.Uint64Builder).Append(v.Uint64Value)
+			}
+			return ok
+		}
+	case Ydb.Type_FLOAT:
+		dt, app = arrow.PrimitiveTypes.Float32, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_FloatValue)
+			if ok {
+				b.(*array.Float32Builder).Append(v.FloatValue)
+			}
+			return ok
+		}
+	case Ydb.Type_DOUBLE:
+		dt, app = arrow.PrimitiveTypes.Float64, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_DoubleValue)
+			if ok {
+				b.(*array.Float64Builder).Append(v.DoubleValue)
+			}
+			return ok
+		}
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER,
+		Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP:
+		dt, app = arrow.BinaryTypes.String, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_TextValue)
+			if ok {
+				b.(*array.StringBuilder).Append(v.TextValue)
+			}
+			return ok
+		}
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		dt, app = arrow.BinaryTypes.Binary, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_BytesValue)
+			if ok {
+				b.(*array.BinaryBuilder).Append(v.BytesValue)
+			}
+			return ok
+		}
+	case Ydb.Type_UUID:
+		dt, app = &arrow.FixedSizeBinaryType{ByteWidth: 16}, func(b array.Builder, val *Ydb.Value) bool { //nolint:mnd // uuid size
+			u, err := types.DecodeUUID(typ, val)
+			if err == nil {
+				b.(*array.FixedSizeBinaryBuilder).Append(u[:])
+			}
+			return err == nil
+		}
+	case Ydb.Type_DATE:
+		dt, app = arrow.FixedWidthTypes.Date32, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint32Value)
+			if ok {
+				b.(*array.Date32Builder).Append(arrow.Date32(v.Uint32Value)) //nolint:gosec // days fit into int32
+			}
+			return ok
+		}
+	case types.TypeDate32:
+		dt, app = arrow.FixedWidthTypes.Date32, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int32Value)
+			if ok {
+				b.(*array.Date32Builder).Append(arrow.Date32(v.Int32Value))
+			}
+			return ok
+		}
+	case Ydb.Type_DATETIME:
+		dt, app = arrow.FixedWidthTypes.Timestamp_s, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint32Value)
+			if ok {
+				b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.Uint32Value))
+			}
+			return ok
+		}
+	case Ydb.Type_TIMESTAMP:
+		dt, app = arrow.FixedWidthTypes.Timestamp_us, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Uint64Value)
+			if ok {
+				b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.Uint64Value)) //nolint:gosec // timestamps fit into int64
+			}
+			return ok
+		}
+	case types.TypeDatetime64, types.TypeTimestamp64:
+		dt = arrow.FixedWidthTypes.Timestamp_us
+		if id == types.TypeDatetime64 {
+			dt = arrow.FixedWidthTypes.Timestamp_s
+		}
+		app = func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int64Value)
+			if ok {
+				b.(*array.TimestampBuilder).Append(arrow.Timestamp(v.Int64Value))
+			}
+			return ok
+		}
+	case Ydb.Type_INTERVAL, types.TypeInterval64:
+		dt, app = arrow.FixedWidthTypes.Duration_us, func(b array.Builder, val *Ydb.Value) bool {
+			v, ok := val.GetValue().(*Ydb.Value_Int64Value)
+			if ok {
+				b.(*array.DurationBuilder).Append(arrow.Duration(v.Int64Value))
+			}
+			return ok
+		}
+	default:
+		return field{}, fmt.Errorf("%w: %s", ErrUnsupported, types.FormatType(typ))
+	}
+
+	return field{
+		typ: dt,
+		append: func(b array.Builder, val *Ydb.Value) error {
+			if !app(b, val) {
+				return mismatch(typ)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+func mismatch(typ *Ydb.Type) error {
+	return fmt.Errorf("%w: expected %s", ErrValue, types.FormatType(typ))
+}
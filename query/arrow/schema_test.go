@@ -0,0 +1,58 @@
+package arrow
+
+import (
+	"testing"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func column(t *testing.T, name, typ string) *Ydb.Column {
+	t.Helper()
+
+	parsed, err := types.ParseType(typ)
+	require.NoError(t, err)
+
+	return &Ydb.Column{Name: name, Type: parsed}
+}
+
+func TestSchema(t *testing.T) {
+	schema, err := Schema([]*Ydb.Column{
+		column(t, "id", "Uint64"),
+		column(t, "name", "Utf8?"),
+		column(t, "amount", "Decimal(22,9)"),
+		column(t, "created", "Timestamp"),
+		column(t, "day", "Date32"),
+		column(t, "ttl", "Interval"),
+		column(t, "uid", "Uuid"),
+		column(t, "tags", "List<Utf8?>"),
+		column(t, "attrs", "Dict<Utf8,Int64>"),
+		column(t, "pair", "Tuple<Int32,String>"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "schema:\n  fields: 10\n"+
+		"    - id: type=uint64\n"+
+		"    - name: type=utf8, nullable\n"+
+		"    - amount: type=decimal(22, 9)\n"+
+		"    - created: type=timestamp[us, tz=UTC]\n"+
+		"    - day: type=date32\n"+
+		"    - ttl: type=duration[us]\n"+
+		"    - uid: type=fixed_size_binary[16]\n"+
+		"    - tags: type=list<item: utf8, nullable>\n"+
+		"    - attrs: type=map<utf8, int64, items_nullable>\n"+
+		"    - pair: type=struct<0: int32, 1: binary>", schema.String())
+	assert.True(t, arrow.TypeEqual(arrow.PrimitiveTypes.Uint64, schema.Field(0).Type))
+}
+
+func TestSchema_Unsupported(t *testing.T) {
+	for _, typ := range []string{"Variant<Int32,Utf8>", "EmptyList", "PgInt4", "Dict<Int32?,Utf8>"} {
+		_, err := Schema([]*Ydb.Column{column(t, "col", typ)})
+		require.ErrorIs(t, err, ErrUnsupported, typ)
+		assert.Contains(t, err.Error(), `column "col"`)
+	}
+}
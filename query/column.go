@@ -0,0 +1,378 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrColumnType = errors.New("unsupported column type")
+)
+
+type (
+	// Column is a columnar view of result set column. Values are decoded
+	// into typed slice once, NULL values are zero values of slice type
+	// and are marked in validity bitmap.
+	//
+	// Slice type depends on column type:
+	//   - Bool: []bool
+	//   - IntN: []int64, UintN: []uint64, Float and Double: []float64
+	//   - Utf8, Json, JsonDocument and DyNumber: []string, String and Yson: [][]byte
+	//   - date and time types: []time.Time, Interval and Interval64: []time.Duration
+	//   - Decimal: []types.DecimalValue, Uuid: []types.UUIDValue
+	//
+	// Optional types have the same slices as their items.
+	Column struct {
+		values any
+		typ    *Ydb.Type
+		valid  Bitmap
+		name   string
+		length int
+	}
+
+	// Bitmap is a validity bitmap of column values: bit i%64 of word i/64
+	// is set if value i is not NULL. Nil bitmap means that all values are valid.
+	Bitmap []uint64
+
+	// columnCache holds columns which were already built.
+	columnCache struct {
+		columns []*Column
+		mu      sync.Mutex
+	}
+)
+
+// Valid reports whether value i is not NULL.
+func (b Bitmap) Valid(i int) bool {
+	if b == nil {
+		return true
+	}
+
+	return b[i>>6]&(1<<(i&63)) != 0
+}
+
+func (b Bitmap) set(i int) {
+	b[i>>6] |= 1 << (i & 63)
+}
+
+// Name returns column name.
+func (c *Column) Name() string {
+	return c.name
+}
+
+// Type returns column type.
+func (c *Column) Type() *Ydb.Type {
+	return c.typ
+}
+
+// Len returns number of values in column.
+func (c *Column) Len() int {
+	return c.length
+}
+
+// Validity returns validity bitmap of column values, it is nil if column type is not Optional.
+func (c *Column) Validity() Bitmap {
+	return c.valid
+}
+
+// Valid reports whether value i is not NULL.
+func (c *Column) Valid(i int) bool {
+	return c.valid.Valid(i)
+}
+
+// Bools returns values of Bool column.
+func (c *Column) Bools() ([]bool, error) { return columnValues[bool](c) }
+
+// Int64s returns values of signed integer column.
+func (c *Column) Int64s() ([]int64, error) { return columnValues[int64](c) }
+
+// Uint64s returns values of unsigned integer column.
+func (c *Column) Uint64s() ([]uint64, error) { return columnValues[uint64](c) }
+
+// Float64s returns values of Float or Double column.
+func (c *Column) Float64s() ([]float64, error) { return columnValues[float64](c) }
+
+// Strings returns values of Utf8, Json, JsonDocument or DyNumber column.
+func (c *Column) Strings() ([]string, error) { return columnValues[string](c) }
+
+// Bytes returns values of String or Yson column. Values refer to result memory.
+func (c *Column) Bytes() ([][]byte, error) { return columnValues[[]byte](c) }
+
+// Times returns values of date or time column.
+func (c *Column) Times() ([]time.Time, error) { return columnValues[time.Time](c) }
+
+// Durations returns values of Interval or Interval64 column.
+func (c *Column) Durations() ([]time.Duration, error) { return columnValues[time.Duration](c) }
+
+// Decimals returns values of Decimal column.
+func (c *Column) Decimals() ([]types.DecimalValue, error) { return columnValues[types.DecimalValue](c) }
+
+// UUIDs returns values of Uuid column.
+func (c *Column) UUIDs() ([]types.UUIDValue, error) { return columnValues[types.UUIDValue](c) }
+
+func columnValues[T any](c *Column) ([]T, error) {
+	values, ok := c.values.([]T)
+	if !ok {
+		var zero T
+		return nil, fmt.Errorf("%w: column %q: cannot get []%T values of %s",
+			ErrScanType, c.name, zero, types.FormatType(c.typ))
+	}
+
+	return values, nil
+}
+
+// Column returns columnar view of result set column. It is built
+// on first call and reused afterwards.
+func (rs *ResultSet) Column(name string) (*Column, error) {
+	return rs.columns.column(rs.cols, rs.rows, name)
+}
+
+// Column returns columnar view of the first result set column.
+// See ResultSet.Column.
+func (r *Result) Column(name string) (*Column, error) {
+	return r.firstOrEmpty().Column(name)
+}
+
+// Column returns columnar view of column of result part. It is built
+// on first call and reused afterwards. See ResultSet.Column.
+func (p *ResultPart) Column(name string) (*Column, error) {
+	return p.columns.column(p.Cols, p.ResultSet.GetRows(), name)
+}
+
+func (cc *columnCache) column(cols []*Ydb.Column, rows []*Ydb.Value, name string) (*Column, error) {
+	idx := NewRow(cols, nil).colIndex(name)
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrScanNoColumn, name)
+	}
+
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.columns == nil {
+		cc.columns = make([]*Column, len(cols))
+	}
+	if cc.columns[idx] == nil {
+		col, err := buildColumn(cols[idx], idx, rows)
+		if err != nil {
+			return nil, err
+		}
+		cc.columns[idx] = col
+	}
+
+	return cc.columns[idx], nil
+}
+
+// reset drops built columns, it is called when rows are added.
+func (cc *columnCache) reset() {
+	cc.mu.Lock()
+	cc.columns = nil
+	cc.mu.Unlock()
+}
+
+// buildColumn decodes values of column idx of rows.
+func buildColumn(col *Ydb.Column, idx int, rows []*Ydb.Value) (*Column, error) {
+	typ, depth := col.Type, 0
+	for {
+		if opt := typ.GetOptionalType(); opt != nil {
+			typ, depth = opt.GetItem(), depth+1
+			continue
+		}
+		if tagged := typ.GetTaggedType(); tagged != nil {
+			typ = tagged.GetType()
+			continue
+		}
+		break
+	}
+
+	c := &Column{
+		name:   col.Name,
+		typ:    col.Type,
+		length: len(rows),
+	}
+	set, ok := columnSetter(c, typ, len(rows))
+	if !ok {
+		return nil, fmt.Errorf("%w: column %q: %s", ErrColumnType, col.Name, types.FormatType(col.Type))
+	}
+	if depth > 0 {
+		c.valid = make(Bitmap, (len(rows)+63)/64) //nolint:mnd // bits in word
+	}
+
+	for i, row := range rows {
+		items := row.GetItems()
+		if idx >= len(items) {
+			return nil, fmt.Errorf("%w: row %d has %d values", ErrScanColumns, i, len(items))
+		}
+		val := items[idx]
+		if depth > 0 {
+			var ok bool
+			if val, ok = unwrapOptional(val, depth); !ok {
+				continue
+			}
+			c.valid.set(i)
+		}
+		if err := set(i, val); err != nil {
+			return nil, fmt.Errorf("row %d: column %q: %w", i, col.Name, err)
+		}
+	}
+
+	return c, nil
+}
+
+// unwrapOptional returns value of Optional type with depth nested Optional types.
+// It returns false if value is NULL on any level.
+func unwrapOptional(val *Ydb.Value, depth int) (*Ydb.Value, bool) {
+	for ; depth > 0; depth-- {
+		switch v := val.GetValue().(type) {
+		case *Ydb.Value_NullFlagValue:
+			return nil, false
+		case *Ydb.Value_NestedValue:
+			if depth > 1 {
+				val = v.NestedValue
+			}
+		}
+	}
+
+	return val, true
+}
+
+// columnSetter allocates values slice of column and returns func
+// which decodes value into i-th element of the slice. It returns false
+// if column type is not supported.
+//
+//nolint:cyclop,gocognit,funlen // flat switch over primitive types
+func columnSetter(c *Column, typ *Ydb.Type, n int) (func(i int, val *Ydb.Value) error, bool) {
+	if typ.GetDecimalType() != nil {
+		values := make([]types.DecimalValue, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) (err error) {
+			values[i], err = types.DecodeDecimal(typ, val)
+			return err //nolint:wrapcheck // wrapped by caller
+		}, true
+	}
+
+	id := typ.GetTypeId()
+	unexpected := func(val *Ydb.Value) error {
+		return fmt.Errorf("%w: unexpected value %T for %s", ErrScanType, val.GetValue(), types.FormatType(typ))
+	}
+	switch {
+	case id == Ydb.Type_BOOL:
+		values := make([]bool, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			v, ok := val.GetValue().(*Ydb.Value_BoolValue)
+			if !ok {
+				return unexpected(val)
+			}
+			values[i] = v.BoolValue
+
+			return nil
+		}, true
+	case id == Ydb.Type_INT8 || id == Ydb.Type_INT16 || id == Ydb.Type_INT32 || id == Ydb.Type_INT64:
+		values := make([]int64, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			switch v := val.GetValue().(type) {
+			case *Ydb.Value_Int32Value:
+				values[i] = int64(v.Int32Value)
+			case *Ydb.Value_Int64Value:
+				values[i] = v.Int64Value
+			default:
+				return unexpected(val)
+			}
+
+			return nil
+		}, true
+	case id == Ydb.Type_UINT8 || id == Ydb.Type_UINT16 || id == Ydb.Type_UINT32 || id == Ydb.Type_UINT64:
+		values := make([]uint64, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			switch v := val.GetValue().(type) {
+			case *Ydb.Value_Uint32Value:
+				values[i] = uint64(v.Uint32Value)
+			case *Ydb.Value_Uint64Value:
+				values[i] = v.Uint64Value
+			default:
+				return unexpected(val)
+			}
+
+			return nil
+		}, true
+	case id == Ydb.Type_FLOAT || id == Ydb.Type_DOUBLE:
+		values := make([]float64, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			switch v := val.GetValue().(type) {
+			case *Ydb.Value_FloatValue:
+				values[i] = float64(v.FloatValue)
+			case *Ydb.Value_DoubleValue:
+				values[i] = v.DoubleValue
+			default:
+				return unexpected(val)
+			}
+
+			return nil
+		}, true
+	case id == Ydb.Type_UTF8 || id == Ydb.Type_JSON || id == Ydb.Type_JSON_DOCUMENT || id == Ydb.Type_DYNUMBER:
+		values := make([]string, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			v, ok := val.GetValue().(*Ydb.Value_TextValue)
+			if !ok {
+				return unexpected(val)
+			}
+			values[i] = v.TextValue
+
+			return nil
+		}, true
+	case id == Ydb.Type_STRING || id == Ydb.Type_YSON:
+		values := make([][]byte, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) error {
+			v, ok := val.GetValue().(*Ydb.Value_BytesValue)
+			if !ok {
+				return unexpected(val)
+			}
+			values[i] = v.BytesValue
+
+			return nil
+		}, true
+	case id == Ydb.Type_UUID:
+		values := make([]types.UUIDValue, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) (err error) {
+			values[i], err = types.DecodeUUID(typ, val)
+			return err //nolint:wrapcheck // wrapped by caller
+		}, true
+	case types.IsTime(id):
+		values := make([]time.Time, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) (err error) {
+			values[i], err = types.DecodeTime(typ, val)
+			return err //nolint:wrapcheck // wrapped by caller
+		}, true
+	case types.IsDuration(id):
+		values := make([]time.Duration, n)
+		c.values = values
+
+		return func(i int, val *Ydb.Value) (err error) {
+			values[i], err = types.DecodeDuration(typ, val)
+			return err //nolint:wrapcheck // wrapped by caller
+		}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,100 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestResultSet_Column(t *testing.T) {
+	set := exportTestSet(t)
+
+	ids, err := set.Column("id")
+	require.NoError(t, err)
+	assert.Equal(t, 2, ids.Len())
+	assert.Nil(t, ids.Validity())
+	idValues, err := ids.Uint64s()
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2}, idValues)
+
+	names, err := set.Column("name")
+	require.NoError(t, err)
+	nameValues, err := names.Strings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", ""}, nameValues)
+	assert.True(t, names.Valid(0))
+	assert.False(t, names.Valid(1))
+	assert.Equal(t, Bitmap{1}, names.Validity())
+
+	ts, err := set.Column("ts")
+	require.NoError(t, err)
+	times, err := ts.Times()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC), times[1])
+
+	data, err := set.Column("data")
+	require.NoError(t, err)
+	bytesValues, err := data.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("hi"), []byte("hi")}, bytesValues)
+
+	// column is built once
+	again, err := set.Column("id")
+	require.NoError(t, err)
+	assert.Same(t, ids, again)
+
+	_, err = ids.Strings()
+	require.ErrorIs(t, err, ErrScanType)
+	_, err = set.Column("tags")
+	require.ErrorIs(t, err, ErrColumnType)
+	_, err = set.Column("unknown")
+	require.ErrorIs(t, err, ErrScanNoColumn)
+}
+
+func TestResultSet_ColumnNested(t *testing.T) {
+	typ := types.Optional(types.Optional(types.Int32(0))).Type
+	set := &ResultSet{
+		cols: []*Ydb.Column{{Name: "v", Type: typ}},
+		rows: []*Ydb.Value{
+			{Items: []*Ydb.Value{types.Optional(types.Optional(types.Int32(-1))).Value}},
+			{Items: []*Ydb.Value{types.Optional(types.NullOf(types.Int32(0).Type)).Value}},
+			{Items: []*Ydb.Value{types.NullOf(types.Optional(types.Int32(0)).Type).Value}},
+		},
+	}
+
+	col, err := set.Column("v")
+	require.NoError(t, err)
+	values, err := col.Int64s()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{-1, 0, 0}, values)
+	assert.Equal(t, []bool{true, false, false}, []bool{col.Valid(0), col.Valid(1), col.Valid(2)})
+
+	set.rows = append(set.rows, &Ydb.Value{Items: []*Ydb.Value{types.UTF8("x").Value}})
+	set.columns.reset()
+	_, err = set.Column("v")
+	require.ErrorIs(t, err, ErrScanType)
+	assert.Contains(t, err.Error(), `row 3: column "v"`)
+}
+
+func TestResultPart_Column(t *testing.T) {
+	rs, _ := newTestStream(&fakeStream{parts: testParts()})
+	require.NoError(t, rs.prefetch())
+
+	var sum uint64
+	for part, err := range rs.Parts() {
+		require.NoError(t, err)
+		col, err := part.Column("id")
+		require.NoError(t, err)
+		values, err := col.Uint64s()
+		require.NoError(t, err)
+		for _, v := range values {
+			sum += v
+		}
+	}
+	assert.Equal(t, uint64(6), sum)
+}
@@ -7,7 +7,10 @@ import (
 
 	"github.com/adwski/ydb-go-query/internal/logger"
 	"github.com/adwski/ydb-go-query/internal/query"
+	"github.com/adwski/ydb-go-query/internal/query/session"
 	"github.com/adwski/ydb-go-query/internal/query/txsettings"
+	"github.com/adwski/ydb-go-query/internal/retry"
+	"github.com/adwski/ydb-go-query/internal/xcontext"
 
 	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -24,9 +27,18 @@ var (
 
 type Ctx struct {
 	qSvc    *query.Service
+	acquire func(context.Context) (txSession, func(), error)
 	txSet   *Ydb_Query.TransactionSettings
 	logger  logger.Logger
 	timeout time.Duration
+
+	retryAttempts int
+	idempotent    bool
+	autoDeclare   bool
+	statsMode     Ydb_Query.StatsMode
+	syntax        Ydb_Query.Syntax
+	logParams     bool
+	redactParams  bool
 }
 
 func NewCtx(
@@ -34,12 +46,23 @@ func NewCtx(
 	qSvc *query.Service,
 	txSet *Ydb_Query.TransactionSettings,
 	timeout time.Duration,
+	retryAttempts int,
 ) *Ctx {
 	return &Ctx{
 		logger:  logger,
 		qSvc:    qSvc,
 		txSet:   txSet,
 		timeout: timeout,
+		acquire: func(ctx context.Context) (txSession, func(), error) {
+			sess, cleanup, err := qSvc.AcquireSession(ctx)
+			if err != nil {
+				return nil, nil, err //nolint:wrapcheck //unnecessary
+			}
+
+			return sess, cleanup, nil
+		},
+
+		retryAttempts: retryAttempts,
 	}
 }
 
@@ -77,66 +100,234 @@ func (qc *Ctx) SerializableReadWrite() *Ctx {
 	return &newQCtx
 }
 
+// Idempotent returns query context which treats all queries as idempotent.
+// Such queries are also retried on errors which leave them
+// in undetermined state (for example transport errors).
+func (qc *Ctx) Idempotent() *Ctx {
+	newQCtx := *qc
+	newQCtx.idempotent = true
+
+	return &newQCtx
+}
+
+// AutoDeclare returns query context which generates DECLARE statements
+// for query parameters. Parameters which are already declared in query are left as is.
+// It can be overridden for particular query with Query.AutoDeclare().
+func (qc *Ctx) AutoDeclare() *Ctx {
+	newQCtx := *qc
+	newQCtx.autoDeclare = true
+
+	return &newQCtx
+}
+
+// WithStatsMode returns query context which collects query stats in provided mode.
+// It can be overridden for particular query with Query.Stats().
+func (qc *Ctx) WithStatsMode(mode Ydb_Query.StatsMode) *Ctx {
+	newQCtx := *qc
+	newQCtx.statsMode = mode
+
+	return &newQCtx
+}
+
+// WithSyntax returns query context which executes queries with provided syntax.
+// It can be overridden for particular query with Query.Syntax().
+func (qc *Ctx) WithSyntax(syntax Ydb_Query.Syntax) *Ctx {
+	newQCtx := *qc
+	newQCtx.syntax = syntax
+
+	return &newQCtx
+}
+
+// LogParams returns query context which logs queries together with their parameters.
+// DECLARE statements are replaced with assignments of parameter values, the same way
+// as with Query.Debug(). If redact is true, parameter values are not logged,
+// only their DECLARE statements are added. Queries are logged with trace level.
+func (qc *Ctx) LogParams(redact bool) *Ctx {
+	newQCtx := *qc
+	newQCtx.logParams = true
+	newQCtx.redactParams = redact
+
+	return &newQCtx
+}
+
 func (qc *Ctx) Query(queryContent string) *Query {
-	return newQuery(
+	q := newQuery(
 		queryContent,
-		func(
-			ctx context.Context,
-			queryContent string,
-			params map[string]*Ydb.TypedValue,
-			collectRows func([]*Ydb.Value) error,
-			timeout time.Duration,
-		) (*Result, error) {
-			return qc.exec(ctx, queryContent, params, collectRows, qc.txSet, timeout)
+		func(ctx context.Context, q *Query) (*Result, error) {
+			return qc.exec(ctx, q, qc.txSet)
+		},
+		func(ctx context.Context, q *Query) (*ResultStream, error) {
+			return qc.stream(ctx, q, qc.txSet)
 		},
 	)
+	q.autoDeclare = qc.autoDeclare
+	q.statsMode = qc.statsMode
+	q.syntax = qc.syntax
+
+	return q
 }
 
 func (qc *Ctx) Exec(ctx context.Context, queryContent string) (*Result, error) {
-	return qc.exec(ctx, queryContent, nil, nil, nil, 0)
+	return qc.exec(ctx, &Query{content: queryContent, statsMode: qc.statsMode, syntax: qc.syntax}, nil)
+}
+
+// Retry calls op until it succeeds, returns non-retryable error,
+// retry attempts are exhausted or ctx is done.
+// Queries executed inside op are not retried on their own,
+// instead the whole op is repeated.
+func (qc *Ctx) Retry(ctx context.Context, op func(context.Context) error) error {
+	return qc.retry(ctx, qc.retryConfig(false), func(ctx context.Context) error {
+		return op(xcontext.WithRetryScope(ctx))
+	})
+}
+
+func (qc *Ctx) retryConfig(idempotent bool) retry.Config {
+	return retry.Config{
+		Logger:      qc.logger,
+		MaxAttempts: qc.retryAttempts,
+		Idempotent:  idempotent || qc.idempotent,
+	}
+}
+
+func (qc *Ctx) retry(ctx context.Context, cfg retry.Config, op func(context.Context) error) error {
+	if xcontext.InRetryScope(ctx) {
+		// already retried by outer loop
+		return op(ctx)
+	}
+
+	return retry.Do(ctx, cfg, op)
 }
 
-func (qc *Ctx) exec(
+func (qc *Ctx) exec(ctx context.Context, q *Query, txSet *Ydb_Query.TransactionSettings) (*Result, error) {
+	var res *Result
+	err := qc.retry(ctx, qc.retryConfig(q.idempotent), func(ctx context.Context) (err error) {
+		if res, err = qc.execOnce(ctx, q, txSet); err != nil {
+			return err
+		}
+		if res.rowsCollected {
+			// Rows were already passed to collect func,
+			// so query cannot be repeated.
+			return nil
+		}
+
+		return res.Err()
+	})
+	if res != nil {
+		// query errors are reported with Result.Err()
+		return res, nil
+	}
+
+	return nil, err
+}
+
+func (qc *Ctx) execOnce(ctx context.Context, q *Query, txSet *Ydb_Query.TransactionSettings) (*Result, error) {
+	content, params, err := q.render()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, cancel, err := qc.open(ctx, q, content, params, txSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return qc.processResult(stream, cancel, content, q.collectRowsFunc)
+}
+
+func (qc *Ctx) stream(ctx context.Context, q *Query, txSet *Ydb_Query.TransactionSettings) (*ResultStream, error) {
+	var rs *ResultStream
+	err := qc.retry(ctx, qc.retryConfig(q.idempotent), func(ctx context.Context) error {
+		content, params, err := q.render()
+		if err != nil {
+			rs = nil
+			return err
+		}
+
+		stream, cancel, err := qc.open(ctx, q, content, params, txSet)
+		if err != nil {
+			rs = nil
+			return err
+		}
+
+		rs = newResultStream(stream, cancel, qc.logger)
+		rs.query = content
+		if err = rs.prefetch(); err != nil {
+			rs = nil
+			return errors.Join(ErrResult, err)
+		}
+
+		// query error is available if stream
+		// is finished with first part
+		return rs.Err()
+	})
+	if rs != nil {
+		// query errors are reported with ResultStream.Err()
+		return rs, nil
+	}
+
+	return nil, err
+}
+
+// open starts query execution. Query timeout applies to the whole
+// stream lifetime and is released with returned cancel func.
+func (qc *Ctx) open(
 	ctx context.Context,
-	queryContent string,
+	q *Query,
+	content string,
 	params map[string]*Ydb.TypedValue,
-	collectRows func([]*Ydb.Value) error,
 	txSet *Ydb_Query.TransactionSettings,
-	timeout time.Duration,
-) (*Result, error) {
-	var (
-		qCancel context.CancelFunc
-	)
+) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error) {
+	qCancel := func() {}
+	timeout := q.timeout
 	if timeout == 0 {
 		timeout = qc.timeout
 	}
 	if timeout > 0 {
 		ctx, qCancel = context.WithDeadline(ctx, time.Now().Add(timeout))
-		defer qCancel()
 	}
-	stream, cancel, err := qc.qSvc.Exec(ctx, queryContent, params, txSet)
+	if q.execMode != Ydb_Query.ExecMode_EXEC_MODE_UNSPECIFIED {
+		// query is not executed, so transaction is not needed
+		txSet = nil
+	}
+	stream, cancel, err := qc.qSvc.Exec(ctx, content, params, txSet, session.ExecOptions{
+		ConcurrentResultSets: q.concurrentResultSets,
+		StatsMode:            q.statsMode,
+		ExecMode:             q.execMode,
+		Syntax:               q.syntax,
+	})
 	if err != nil {
-		return nil, err //nolint:wrapcheck //unnecessary
+		qCancel()
+		return nil, nil, err //nolint:wrapcheck //unnecessary
 	}
 
 	qc.logger.TraceFunc(func() (string, []any) {
-		return "received result stream", []any{"query", strip(queryContent)}
+		return "received result stream", []any{"query", strip(qc.logQuery(content, params, q.syntax))}
 	})
 
-	return qc.processResult(stream, cancel, collectRows)
+	return stream, func() {
+		cancel()
+		qCancel()
+	}, nil
 }
 
 func (qc *Ctx) Tx(ctx context.Context) (*Transaction, error) {
-	sess, cleanup, err := qc.qSvc.AcquireSession(ctx)
+	return qc.tx(ctx, qc.txSet)
+}
+
+func (qc *Ctx) tx(ctx context.Context, settings *Ydb_Query.TransactionSettings) (*Transaction, error) {
+	sess, cleanup, err := qc.acquire(ctx)
 	if err != nil {
-		return nil, err //nolint:wrapcheck //unnecessary
+		return nil, err
 	}
 
 	tx := &Transaction{
-		logger:   qc.logger,
-		settings: qc.txSet,
-		sess:     sess,
-		cleanup:  cleanup,
+		logger:      qc.logger,
+		autoDeclare: qc.autoDeclare,
+		statsMode:   qc.statsMode,
+		syntax:      qc.syntax,
+		settings:    settings,
+		sess:        sess,
+		cleanup:     cleanup,
 	}
 
 	return tx, nil
@@ -145,9 +336,11 @@ func (qc *Ctx) Tx(ctx context.Context) (*Transaction, error) {
 func (qc *Ctx) processResult(
 	stream Ydb_Query_V1.QueryService_ExecuteQueryClient,
 	cancel context.CancelFunc,
-	collectRows func([]*Ydb.Value) error,
+	content string,
+	collectRows CollectFunc,
 ) (*Result, error) {
 	res := newResult(stream, cancel, qc.logger, collectRows)
+	res.query = content
 
 	if err := res.recv(); err != nil {
 		return nil, errors.Join(ErrResult, err)
@@ -156,6 +349,30 @@ func (qc *Ctx) processResult(
 	return res, nil
 }
 
+// logQuery returns query content to be logged. If parameters are logged,
+// they are rendered into query content. Content is returned as is
+// if it cannot be rendered.
+func (qc *Ctx) logQuery(content string, params map[string]*Ydb.TypedValue, syntax Ydb_Query.Syntax) string {
+	if !qc.logParams || syntax == SyntaxPG {
+		return content
+	}
+
+	var (
+		script string
+		err    error
+	)
+	if qc.redactParams {
+		script, err = withDeclares(content, params)
+	} else {
+		script, err = debugScript(content, params)
+	}
+	if err != nil {
+		return content
+	}
+
+	return script
+}
+
 func strip(s string) string {
 	if len(s) > maxQueryLogLength {
 		b := []byte(s[:maxQueryLogLength-2])
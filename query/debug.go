@@ -0,0 +1,104 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+)
+
+var (
+	ErrDebug = errors.New("cannot render query script")
+)
+
+// debug returns standalone YQL script of query with parameters assigned to their values.
+func debug(
+	content string,
+	params map[string]*Ydb.TypedValue,
+	args *goArgs,
+	syntax Ydb_Query.Syntax,
+) (string, error) {
+	if syntax == SyntaxPG {
+		return "", fmt.Errorf("%w: PostgreSQL syntax is not supported", ErrDebug)
+	}
+
+	params, err := withArgs(params, args, syntax)
+	if err != nil {
+		return "", err
+	}
+
+	return debugScript(content, params)
+}
+
+// debugScript returns query content with DECLARE statements of params replaced
+// with assignments of YQL literals, like $id = Uint64("1");. Assignments of params
+// which are not declared in content are inserted the same way as by withDeclares.
+func debugScript(content string, params map[string]*Ydb.TypedValue) (string, error) {
+	if len(params) == 0 {
+		return content, nil
+	}
+
+	declared, pos := scanDeclares(content)
+
+	literals := make(map[string]string, len(params))
+	for name, tv := range params {
+		lit, err := types.Literal(tv)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s: %w", ErrDebug, name, err)
+		}
+		literals[name] = lit
+	}
+
+	type replacement struct {
+		name string
+		span
+	}
+	var (
+		replacements = make([]replacement, 0, len(params))
+		undeclared   = make([]string, 0, len(params))
+	)
+	for name := range params {
+		if sp, ok := declared[name]; ok {
+			replacements = append(replacements, replacement{name: name, span: sp})
+		} else {
+			undeclared = append(undeclared, name)
+		}
+	}
+	slices.Sort(undeclared)
+	// statements are replaced in order of their position in content
+	slices.SortFunc(replacements, func(a, b replacement) int {
+		return a.start - b.start
+	})
+
+	var b strings.Builder
+	writeAssign := func(name string) {
+		b.WriteString(name)
+		b.WriteString(" = ")
+		b.WriteString(literals[name])
+		b.WriteByte(';')
+	}
+
+	// DECLARE statements are never placed before pos
+	b.WriteString(content[:pos])
+	if len(undeclared) > 0 && pos > 0 && content[pos-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	for _, name := range undeclared {
+		writeAssign(name)
+		b.WriteByte('\n')
+	}
+	last := pos
+	for _, r := range replacements {
+		b.WriteString(content[last:r.start])
+		writeAssign(r.name)
+		last = r.end
+	}
+	b.WriteString(content[last:])
+
+	return b.String(), nil
+}
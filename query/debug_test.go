@@ -0,0 +1,77 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestDebugScript(t *testing.T) {
+	params := map[string]*Ydb.TypedValue{
+		"$id":   types.Uint64(1),
+		"$name": types.UTF8("a"),
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "no declares",
+			content: "SELECT $id, $name;",
+			want:    "$id = Uint64(\"1\");\n$name = Utf8(\"a\");\nSELECT $id, $name;",
+		},
+		{
+			name:    "replace declares",
+			content: "PRAGMA TablePathPrefix(\"/local\");\nDECLARE $name AS Utf8;\nDECLARE $id AS Uint64;\nSELECT $id, $name;",
+			want: "PRAGMA TablePathPrefix(\"/local\");\n$name = Utf8(\"a\");\n$id = Uint64(\"1\");\n" +
+				"SELECT $id, $name;",
+		},
+		{
+			name:    "merge with existing",
+			content: "declare $id as Uint64; SELECT $id, $name;",
+			want:    "$name = Utf8(\"a\");\n$id = Uint64(\"1\"); SELECT $id, $name;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := debugScript(tt.content, params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := debugScript("SELECT $id;", map[string]*Ydb.TypedValue{"$id": {Type: types.Uint64(0).Type}})
+	require.ErrorIs(t, err, ErrDebug)
+}
+
+func TestQuery_Debug(t *testing.T) {
+	qc := (&Ctx{}).AutoDeclare()
+
+	q := qc.Query("SELECT $id, $p1;").Param("$id", types.Int32(1)).Args("a")
+	script, err := q.Debug()
+	require.NoError(t, err)
+	assert.Equal(t, "$id = Int32(\"1\");\n$p1 = Utf8(\"a\");\nSELECT $id, $p1;", script)
+	assert.Equal(t, script, q.String())
+
+	q = qc.Query("SELECT $1;").Syntax(SyntaxPG).Args(1)
+	_, err = q.Debug()
+	require.ErrorIs(t, err, ErrDebug)
+	assert.Equal(t, "SELECT $1;", q.String())
+}
+
+func TestCtx_LogParams(t *testing.T) {
+	params := map[string]*Ydb.TypedValue{"$id": types.Uint64(1)}
+	content := "DECLARE $id AS Uint64;\nSELECT $id;"
+
+	qc := &Ctx{}
+	assert.Equal(t, content, qc.logQuery(content, params, SyntaxYQL))
+	assert.Equal(t, "$id = Uint64(\"1\");\nSELECT $id;", qc.LogParams(false).logQuery(content, params, SyntaxYQL))
+	assert.Equal(t, content, qc.LogParams(true).logQuery(content, params, SyntaxYQL))
+	assert.Equal(t, "DECLARE $id AS Uint64;\nSELECT $id;", qc.LogParams(true).logQuery("SELECT $id;", params, SyntaxYQL))
+}
@@ -0,0 +1,201 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrDeclare = errors.New("cannot declare parameter")
+)
+
+// withDeclares returns query content with DECLARE statements generated for params
+// which are not declared in content already. Generated statements are inserted
+// after leading comments and PRAGMA statements.
+func withDeclares(content string, params map[string]*Ydb.TypedValue) (string, error) {
+	if len(params) == 0 {
+		return content, nil
+	}
+
+	declared, pos := scanDeclares(content)
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if _, ok := declared[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return content, nil
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	b.WriteString(content[:pos])
+	if pos > 0 && content[pos-1] != '\n' {
+		b.WriteByte('\n')
+	}
+	for _, name := range names {
+		typ := params[name].GetType()
+		if typ == nil {
+			return "", fmt.Errorf("%w %s: type is not set", ErrDeclare, name)
+		}
+		decl := types.FormatType(typ)
+		if _, err := types.ParseType(decl); err != nil {
+			// type cannot be represented in YQL
+			return "", fmt.Errorf("%w %s: %w", ErrDeclare, name, err)
+		}
+		b.WriteString("DECLARE ")
+		b.WriteString(name)
+		b.WriteString(" AS ")
+		b.WriteString(decl)
+		b.WriteString(";\n")
+	}
+	b.WriteString(content[pos:])
+
+	return b.String(), nil
+}
+
+// span is a position of statement in query content, end is after terminating semicolon.
+type span struct {
+	start, end int
+}
+
+// scanDeclares returns DECLARE statements of parameters in query and position
+// of first statement which is not a PRAGMA. Comments and literals are skipped.
+func scanDeclares(s string) (map[string]span, int) {
+	var (
+		declared    = make(map[string]span)
+		pos         = -1
+		atStmtStart = true
+		pending     string
+		pendingAt   int
+	)
+
+	startStmt := func(i int, word string) {
+		if pos < 0 && !strings.EqualFold(word, "PRAGMA") {
+			pos = i
+		}
+		atStmtStart = false
+	}
+	endStmt := func(end int) {
+		if pending != "" {
+			declared[pending] = span{start: pendingAt, end: end}
+			pending = ""
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if j := skipSpace(s, i); j != i {
+			i = j
+			continue
+		}
+
+		switch c := s[i]; {
+		case c == ';':
+			atStmtStart = true
+			i++
+			endStmt(i)
+		case isIdentStart(c):
+			end := identEnd(s, i)
+			if atStmtStart {
+				word := s[i:end]
+				startStmt(i, word)
+				if strings.EqualFold(word, "DECLARE") {
+					pending, pendingAt = paramName(s, skipSpace(s, end)), i
+				}
+			}
+			i = end
+		default:
+			if atStmtStart {
+				startStmt(i, "")
+			}
+			i = skipLiteral(s, i)
+		}
+	}
+	endStmt(len(s))
+	if pos < 0 {
+		pos = len(s)
+	}
+
+	return declared, pos
+}
+
+// skipSpace returns position of the first char after whitespaces and comments.
+func skipSpace(s string, i int) int {
+	for i < len(s) {
+		switch {
+		case s[i] == ' ', s[i] == '\t', s[i] == '\n', s[i] == '\r':
+			i++
+		case strings.HasPrefix(s[i:], "--"):
+			end := strings.IndexByte(s[i:], '\n')
+			if end < 0 {
+				return len(s)
+			}
+			i += end + 1
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				return len(s)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// skipLiteral returns position after string literal or quoted identifier
+// starting at i, or i+1 if there is no literal at i.
+func skipLiteral(s string, i int) int {
+	if strings.HasPrefix(s[i:], "@@") {
+		end := strings.Index(s[i+2:], "@@")
+		if end < 0 {
+			return len(s)
+		}
+		return i + end + 4
+	}
+
+	quote := s[i]
+	if quote != '\'' && quote != '"' && quote != '`' {
+		return i + 1
+	}
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		}
+	}
+
+	return len(s)
+}
+
+func paramName(s string, i int) string {
+	if i >= len(s) || s[i] != '$' {
+		return ""
+	}
+
+	return s[i:identEnd(s, i+1)]
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func identEnd(s string, i int) int {
+	for i < len(s) && (isIdentStart(s[i]) || (s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+
+	return i
+}
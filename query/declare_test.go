@@ -0,0 +1,86 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestWithDeclares(t *testing.T) {
+	params := map[string]*Ydb.TypedValue{
+		"$id":   types.Uint64(1),
+		"$name": types.UTF8("a"),
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "no declares",
+			content: "SELECT $id, $name;",
+			want:    "DECLARE $id AS Uint64;\nDECLARE $name AS Utf8;\nSELECT $id, $name;",
+		},
+		{
+			name:    "merge with existing",
+			content: "declare $id as Uint64;\nSELECT $id, $name;",
+			want:    "DECLARE $name AS Utf8;\ndeclare $id as Uint64;\nSELECT $id, $name;",
+		},
+		{
+			name:    "all declared",
+			content: "DECLARE $id AS Uint64; DECLARE $name AS Utf8; SELECT $id, $name;",
+			want:    "DECLARE $id AS Uint64; DECLARE $name AS Utf8; SELECT $id, $name;",
+		},
+		{
+			name:    "after pragmas and comments",
+			content: "--!syntax_v1\nPRAGMA TablePathPrefix(\"/local;\"); -- DECLARE $id AS Uint64;\nSELECT $id, $name;",
+			want: "--!syntax_v1\nPRAGMA TablePathPrefix(\"/local;\"); -- DECLARE $id AS Uint64;\n" +
+				"DECLARE $id AS Uint64;\nDECLARE $name AS Utf8;\nSELECT $id, $name;",
+		},
+		{
+			name:    "declare in literal",
+			content: "/* DECLARE $name AS Utf8; */ SELECT 'DECLARE $id AS Uint64;', $id, $name;",
+			want: "/* DECLARE $name AS Utf8; */ \nDECLARE $id AS Uint64;\nDECLARE $name AS Utf8;\n" +
+				"SELECT 'DECLARE $id AS Uint64;', $id, $name;",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := withDeclares(tt.content, params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWithDeclares_Errors(t *testing.T) {
+	_, err := withDeclares("SELECT $id;", map[string]*Ydb.TypedValue{"$id": {}})
+	require.ErrorIs(t, err, ErrDeclare)
+
+	_, err = withDeclares("SELECT $id;", map[string]*Ydb.TypedValue{"$id": {
+		Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED}},
+	}})
+	require.ErrorIs(t, err, ErrDeclare)
+
+	got, err := withDeclares("SELECT 1;", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT 1;", got)
+}
+
+func TestQuery_AutoDeclare(t *testing.T) {
+	qc := (&Ctx{}).AutoDeclare()
+
+	q := qc.Query("SELECT $id;").Param("$id", types.Int32(1))
+	content, _, err := q.render()
+	require.NoError(t, err)
+	assert.Equal(t, "DECLARE $id AS Int32;\nSELECT $id;", content)
+
+	content, _, err = q.AutoDeclare(false).render()
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT $id;", content)
+}
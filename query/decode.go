@@ -0,0 +1,380 @@
+package query
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrScanDest     = errors.New("invalid scan destination")
+	ErrScanType     = errors.New("type mismatch")
+	ErrScanNull     = errors.New("NULL cannot be scanned into non-nullable destination")
+	ErrScanOverflow = errors.New("value overflows destination")
+)
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+	decimalType  = reflect.TypeFor[types.DecimalValue]()
+	ratType      = reflect.TypeFor[big.Rat]()
+)
+
+// scanValue decodes YDB value of provided type into destination.
+// Destination must be settable.
+func scanValue(dst reflect.Value, typ *Ydb.Type, val *Ydb.Value) error {
+	if opt, ok := typ.Type.(*Ydb.Type_OptionalType); ok {
+		if _, isNull := val.Value.(*Ydb.Value_NullFlagValue); isNull {
+			return scanNull(dst)
+		}
+
+		item := opt.OptionalType.Item
+		if nested, ok := val.Value.(*Ydb.Value_NestedValue); ok {
+			// optional of optional
+			val = nested.NestedValue
+		}
+
+		return scanValue(dst, item, val)
+	}
+
+	if scanner, ok := asScanner(dst); ok {
+		goVal, err := goValue(typ, val)
+		if err != nil {
+			return err
+		}
+
+		return scanner.Scan(goVal) //nolint:wrapcheck // user defined error
+	}
+
+	switch dst.Kind() { //nolint:exhaustive // other kinds are handled below
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return scanValue(dst.Elem(), typ, val)
+	case reflect.Interface:
+		if dst.NumMethod() > 0 {
+			return mismatch(typ, dst)
+		}
+		goVal, err := goValue(typ, val)
+		if err != nil {
+			return err
+		}
+		if goVal == nil {
+			dst.SetZero()
+		} else {
+			dst.Set(reflect.ValueOf(goVal))
+		}
+
+		return nil
+	}
+
+	switch t := typ.Type.(type) {
+	case *Ydb.Type_TypeId:
+		return scanPrimitive(dst, t.TypeId, typ, val)
+	case *Ydb.Type_ListType:
+		return scanList(dst, t.ListType.Item, typ, val)
+	case *Ydb.Type_DecimalType:
+		return scanDecimal(dst, typ, val)
+	default:
+		return mismatch(typ, dst)
+	}
+}
+
+func scanNull(dst reflect.Value) error {
+	if scanner, ok := asScanner(dst); ok {
+		return scanner.Scan(nil) //nolint:wrapcheck // user defined error
+	}
+
+	switch dst.Kind() { //nolint:exhaustive // other kinds are not nullable
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		dst.SetZero()
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrScanNull, dst.Type())
+	}
+}
+
+func asScanner(dst reflect.Value) (sql.Scanner, bool) {
+	if dst.Kind() == reflect.Pointer || !dst.CanAddr() {
+		return nil, false
+	}
+	scanner, ok := dst.Addr().Interface().(sql.Scanner)
+
+	return scanner, ok
+}
+
+func scanList(dst reflect.Value, item, typ *Ydb.Type, val *Ydb.Value) error {
+	if dst.Kind() != reflect.Slice {
+		return mismatch(typ, dst)
+	}
+
+	list := reflect.MakeSlice(dst.Type(), len(val.Items), len(val.Items))
+	for i, itemVal := range val.Items {
+		if err := scanValue(list.Index(i), item, itemVal); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	dst.Set(list)
+
+	return nil
+}
+
+//nolint:cyclop // flat switch over primitive types
+func scanPrimitive(dst reflect.Value, id Ydb.Type_PrimitiveTypeId, typ *Ydb.Type, val *Ydb.Value) error {
+	if types.IsTime(id) || types.IsDuration(id) {
+		return scanTemporal(dst, typ, val)
+	}
+	if id == Ydb.Type_UUID {
+		return scanUUID(dst, typ, val)
+	}
+
+	var ok bool
+	switch id { //nolint:exhaustive // unsupported types are reported as mismatch
+	case Ydb.Type_BOOL:
+		if ok = dst.Kind() == reflect.Bool; ok {
+			dst.SetBool(val.GetBoolValue())
+		}
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32:
+		return setInt(dst, int64(val.GetInt32Value()), typ)
+	case Ydb.Type_INT64:
+		return setInt(dst, val.GetInt64Value(), typ)
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32:
+		return setUint(dst, uint64(val.GetUint32Value()), typ)
+	case Ydb.Type_UINT64:
+		return setUint(dst, val.GetUint64Value(), typ)
+	case Ydb.Type_FLOAT:
+		ok = setFloat(dst, float64(val.GetFloatValue()))
+	case Ydb.Type_DOUBLE:
+		ok = setFloat(dst, val.GetDoubleValue())
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
+		ok = setString(dst, val.GetTextValue())
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		ok = setBytes(dst, val.GetBytesValue())
+	}
+
+	if !ok {
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+func setInt(dst reflect.Value, v int64, typ *Ydb.Type) error {
+	switch dst.Kind() { //nolint:exhaustive // other kinds are mismatch
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if dst.Type() == durationType {
+			return mismatch(typ, dst)
+		}
+		if dst.OverflowInt(v) {
+			return fmt.Errorf("%w: %d into %s", ErrScanOverflow, v, dst.Type())
+		}
+		dst.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v < 0 || dst.OverflowUint(uint64(v)) {
+			return fmt.Errorf("%w: %d into %s", ErrScanOverflow, v, dst.Type())
+		}
+		dst.SetUint(uint64(v))
+	default:
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+func setUint(dst reflect.Value, v uint64, typ *Ydb.Type) error {
+	switch dst.Kind() { //nolint:exhaustive // other kinds are mismatch
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if dst.OverflowUint(v) {
+			return fmt.Errorf("%w: %d into %s", ErrScanOverflow, v, dst.Type())
+		}
+		dst.SetUint(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if dst.Type() == durationType {
+			return mismatch(typ, dst)
+		}
+		if v > 1<<63-1 || dst.OverflowInt(int64(v)) {
+			return fmt.Errorf("%w: %d into %s", ErrScanOverflow, v, dst.Type())
+		}
+		dst.SetInt(int64(v))
+	default:
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+func setFloat(dst reflect.Value, v float64) bool {
+	switch dst.Kind() { //nolint:exhaustive // other kinds are mismatch
+	case reflect.Float32, reflect.Float64:
+		dst.SetFloat(v)
+		return true
+	default:
+		return false
+	}
+}
+
+func setString(dst reflect.Value, v string) bool {
+	switch {
+	case dst.Kind() == reflect.String:
+		dst.SetString(v)
+	case isBytes(dst.Type()):
+		dst.SetBytes([]byte(v))
+	default:
+		return false
+	}
+
+	return true
+}
+
+func setBytes(dst reflect.Value, v []byte) bool {
+	switch {
+	case dst.Kind() == reflect.String:
+		dst.SetString(string(v))
+	case isBytes(dst.Type()):
+		dst.SetBytes(append([]byte(nil), v...))
+	default:
+		return false
+	}
+
+	return true
+}
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// scanTemporal decodes value of date, time or interval type
+// into time.Time or time.Duration destination.
+func scanTemporal(dst reflect.Value, typ *Ydb.Type, val *Ydb.Value) error {
+	switch {
+	case dst.Type() == timeType && types.IsTime(typ.GetTypeId()):
+		t, err := types.DecodeTime(typ, val)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrScanType, err)
+		}
+		dst.Set(reflect.ValueOf(t))
+	case dst.Type() == durationType && types.IsDuration(typ.GetTypeId()):
+		d, err := types.DecodeDuration(typ, val)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrScanOverflow, err)
+		}
+		dst.SetInt(int64(d))
+	default:
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+// scanDecimal decodes value of Decimal type into types.DecimalValue, big.Rat or string destination.
+func scanDecimal(dst reflect.Value, typ *Ydb.Type, val *Ydb.Value) error {
+	dec, err := types.DecodeDecimal(typ, val)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrScanType, err)
+	}
+
+	switch {
+	case dst.Type() == decimalType:
+		dst.Set(reflect.ValueOf(dec))
+	case dst.Type() == ratType:
+		r, errR := dec.Rat()
+		if errR != nil {
+			return fmt.Errorf("%w: %w", ErrScanOverflow, errR)
+		}
+		dst.Set(reflect.ValueOf(r).Elem())
+	case dst.Kind() == reflect.String:
+		dst.SetString(dec.String())
+	default:
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+// scanUUID decodes value of Uuid type into [16]byte based or string destination.
+func scanUUID(dst reflect.Value, typ *Ydb.Type, val *Ydb.Value) error {
+	u, err := types.DecodeUUID(typ, val)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrScanType, err)
+	}
+
+	switch {
+	case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(u):
+		reflect.Copy(dst, reflect.ValueOf(u[:]))
+	case dst.Kind() == reflect.String:
+		dst.SetString(u.String())
+	default:
+		return mismatch(typ, dst)
+	}
+
+	return nil
+}
+
+// goValue converts YDB value to corresponding Go value using types.ToGo.
+// Signed integers are converted to int64, unsigned to uint64,
+// floats to float64, Decimal to its exact string representation, Uuid to its canonical form,
+// List and Tuple to []any and NULL to nil.
+func goValue(typ *Ydb.Type, val *Ydb.Value) (any, error) {
+	v, err := types.ToGo(typ, val)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrScanType, err)
+	}
+
+	return widen(v), nil
+}
+
+// widen converts values decoded by types.ToGo to widest Go types of their kinds.
+//
+//nolint:cyclop // flat switch over decoded types
+func widen(v any) any {
+	switch tv := v.(type) {
+	case int8:
+		return int64(tv)
+	case int16:
+		return int64(tv)
+	case int32:
+		return int64(tv)
+	case uint8:
+		return uint64(tv)
+	case uint16:
+		return uint64(tv)
+	case uint32:
+		return uint64(tv)
+	case float32:
+		return float64(tv)
+	case types.DecimalValue:
+		return tv.String()
+	case types.UUIDValue:
+		return tv.String()
+	case []any:
+		for i := range tv {
+			tv[i] = widen(tv[i])
+		}
+	case map[string]any:
+		for k := range tv {
+			tv[k] = widen(tv[k])
+		}
+	case map[any]any:
+		for k := range tv {
+			tv[k] = widen(tv[k])
+		}
+	case types.VariantValue:
+		tv.Value = widen(tv.Value)
+		return tv
+	}
+
+	return v
+}
+
+func mismatch(typ *Ydb.Type, dst reflect.Value) error {
+	return fmt.Errorf("%w: cannot scan %s into %s", ErrScanType, types.FormatType(typ), dst.Type())
+}
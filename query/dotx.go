@@ -0,0 +1,131 @@
+package query
+
+import (
+	"context"
+
+	"github.com/adwski/ydb-go-query/internal/xcontext"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+)
+
+type (
+	// TxOption configures DoTx.
+	TxOption func(*txOptions)
+
+	txOptions struct {
+		settings     *Ydb_Query.TransactionSettings
+		maxAttempts  int
+		idempotent   bool
+		inlineCommit bool
+	}
+)
+
+// WithTxSettings sets transaction mode, by default it is inherited from query context.
+func WithTxSettings(settings *Ydb_Query.TransactionSettings) TxOption {
+	return func(o *txOptions) {
+		o.settings = settings
+	}
+}
+
+// WithTxMaxAttempts limits amount of transaction attempts.
+// 1 disables retries, 0 means attempts are limited only by context.
+func WithTxMaxAttempts(attempts int) TxOption {
+	return func(o *txOptions) {
+		if attempts >= 0 {
+			o.maxAttempts = attempts
+		}
+	}
+}
+
+// WithTxIdempotent marks transaction as idempotent. Such transaction is also
+// retried on errors which leave it in undetermined state (for example commit transport errors).
+func WithTxIdempotent() TxOption {
+	return func(o *txOptions) {
+		o.idempotent = true
+	}
+}
+
+// WithTxInlineCommit makes every query of op commit transaction inline, so DoTx
+// does not send separate commit. It is intended for op which executes all statements
+// of transaction with its last (and only) query, queries after it fail with ErrTxFinished.
+// Transaction is committed as soon as the query succeeds, so error returned by op
+// after that does not roll it back.
+func WithTxInlineCommit() TxOption {
+	return func(o *txOptions) {
+		o.inlineCommit = true
+	}
+}
+
+// DoTx runs op inside transaction and commits it if op returns nil.
+// If op returns error or panics, transaction is rolled back.
+// Transaction is also rolled back and not reported as committed if any of its queries
+// has failed, even if op ignores result error. In this case error of failed query is returned.
+//
+// Transaction begins lazily with first query and session is held only during op run.
+// If last query of op is sent with inline commit (TxQuery.Commit() or WithTxInlineCommit),
+// explicit commit is not performed.
+//
+// Whole op is repeated in new transaction if transaction fails with retryable status,
+// for example ABORTED due to transaction locks invalidation.
+// Therefore, op must not have side effects apart from transaction queries.
+func (qc *Ctx) DoTx(ctx context.Context, op func(context.Context, *Transaction) error, opts ...TxOption) error {
+	txOpts := txOptions{
+		settings:    qc.txSet,
+		maxAttempts: qc.retryAttempts,
+	}
+	for _, opt := range opts {
+		opt(&txOpts)
+	}
+
+	cfg := qc.retryConfig(txOpts.idempotent)
+	cfg.MaxAttempts = txOpts.maxAttempts
+
+	return qc.retry(ctx, cfg, func(ctx context.Context) error {
+		return qc.doTx(ctx, op, &txOpts)
+	})
+}
+
+func (qc *Ctx) doTx(
+	ctx context.Context,
+	op func(context.Context, *Transaction) error,
+	opts *txOptions,
+) error {
+	tx, err := qc.tx(ctx, opts.settings)
+	if err != nil {
+		return err
+	}
+	tx.inlineCommit = opts.inlineCommit
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.abort(ctx)
+			panic(p)
+		}
+	}()
+
+	if err = op(xcontext.WithRetryScope(ctx), tx); err != nil {
+		tx.abort(ctx)
+		return err
+	}
+
+	// commit result of inline commit stream is known only after it is finished
+	tx.drainStream()
+
+	if tx.err != nil {
+		// query failed, but op did not return its error
+		tx.abort(ctx)
+		return tx.err
+	}
+
+	if tx.finish {
+		// committed inline
+		return nil
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		tx.abort(ctx)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+	"github.com/adwski/ydb-go-query/internal/query/session"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+)
+
+// fakeSession records transaction calls, every query begins or continues transaction "tx<attempt>".
+type fakeSession struct {
+	// parts are returned by queries instead of single part if set
+	parts []*Ydb_Query.ExecuteQueryResponsePart
+	// statuses are statuses of single parts returned by queries in order, then SUCCESS
+	statuses   []Ydb.StatusIds_StatusCode
+	commitErrs []error
+	calls      []string
+	txControls []*Ydb_Query.TransactionControl
+	acquired   int
+	released   int
+}
+
+func (fs *fakeSession) Exec(
+	_ context.Context,
+	query string,
+	_ map[string]*Ydb.TypedValue,
+	txControl *Ydb_Query.TransactionControl,
+	_ session.ExecOptions,
+) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error) {
+	fs.calls = append(fs.calls, "exec "+query)
+	fs.txControls = append(fs.txControls, txControl)
+	if fs.parts != nil {
+		return &fakeStream{parts: slices.Clone(fs.parts)}, func() {}, nil
+	}
+
+	status := Ydb.StatusIds_SUCCESS
+	if len(fs.statuses) > 0 {
+		status = fs.statuses[0]
+		fs.statuses = fs.statuses[1:]
+	}
+
+	return &fakeStream{parts: []*Ydb_Query.ExecuteQueryResponsePart{{
+		Status: status,
+		TxMeta: &Ydb_Query.TransactionMeta{Id: fmt.Sprintf("tx%d", fs.acquired)},
+	}}}, func() {}, nil
+}
+
+func (fs *fakeSession) CommitTX(_ context.Context, txID string) error {
+	fs.calls = append(fs.calls, "commit "+txID)
+	if len(fs.commitErrs) == 0 {
+		return nil
+	}
+	err := fs.commitErrs[0]
+	fs.commitErrs = fs.commitErrs[1:]
+
+	return err
+}
+
+func (fs *fakeSession) RollbackTX(_ context.Context, txID string) error {
+	fs.calls = append(fs.calls, "rollback "+txID)
+	return nil
+}
+
+func newTestCtx(fs *fakeSession) *Ctx {
+	return &Ctx{
+		logger:        logger.New(noop.NewLogger()),
+		retryAttempts: 5,
+		acquire: func(context.Context) (txSession, func(), error) {
+			fs.acquired++
+			return fs, func() { fs.released++ }, nil
+		},
+	}
+}
+
+func selectOne(ctx context.Context, tx *Transaction) error {
+	_, err := tx.Query("select 1").Exec(ctx)
+	return err
+}
+
+func TestCtx_DoTx(t *testing.T) {
+	fs := &fakeSession{}
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		if err := selectOne(ctx, tx); err != nil {
+			return err
+		}
+		_, err := tx.Query("select 2").Exec(ctx)
+		return err
+	}))
+
+	assert.Equal(t, []string{"exec select 1", "exec select 2", "commit tx1"}, fs.calls)
+	assert.IsType(t, &Ydb_Query.TransactionControl_BeginTx{}, fs.txControls[0].GetTxSelector())
+	assert.Equal(t, "tx1", fs.txControls[1].GetTxId())
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxInlineCommit(t *testing.T) {
+	fs := &fakeSession{}
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		_, err := tx.Query("select 1").Commit().Exec(ctx)
+		return err
+	}))
+
+	assert.Equal(t, []string{"exec select 1"}, fs.calls)
+	assert.True(t, fs.txControls[0].GetCommitTx())
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxRollback(t *testing.T) {
+	errOp := errors.New("op failed")
+
+	fs := &fakeSession{}
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		if err := selectOne(ctx, tx); err != nil {
+			return err
+		}
+		return errOp
+	})
+	require.ErrorIs(t, err, errOp)
+	assert.Equal(t, []string{"exec select 1", "rollback tx1"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+
+	// transaction which was not started is not rolled back
+	fs = &fakeSession{}
+	err = newTestCtx(fs).DoTx(context.Background(), func(context.Context, *Transaction) error {
+		return errOp
+	})
+	require.ErrorIs(t, err, errOp)
+	assert.Empty(t, fs.calls)
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxPanic(t *testing.T) {
+	fs := &fakeSession{}
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+			if err := selectOne(ctx, tx); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+	assert.Equal(t, []string{"exec select 1", "rollback tx1"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxRetry(t *testing.T) {
+	fs := &fakeSession{commitErrs: []error{ydberr.NewOperationError(Ydb.StatusIds_ABORTED, nil)}}
+
+	var runs int
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	}))
+
+	// whole op is repeated in new transaction
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, []string{
+		"exec select 1", "commit tx1", "rollback tx1",
+		"exec select 1", "commit tx2",
+	}, fs.calls)
+	assert.IsType(t, &Ydb_Query.TransactionControl_BeginTx{}, fs.txControls[1].GetTxSelector())
+	assert.Equal(t, 2, fs.acquired)
+	assert.Equal(t, 2, fs.released)
+}
+
+func TestCtx_DoTxMaxAttempts(t *testing.T) {
+	aborted := ydberr.NewOperationError(Ydb.StatusIds_ABORTED, nil)
+	fs := &fakeSession{commitErrs: []error{aborted, aborted, aborted}}
+
+	var runs int
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	}, WithTxMaxAttempts(2))
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_ABORTED))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, 2, fs.released)
+
+	fs = &fakeSession{commitErrs: []error{aborted}}
+	runs = 0
+	err = newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	}, WithTxMaxAttempts(1))
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_ABORTED))
+	assert.Equal(t, 1, runs)
+}
+
+func TestCtx_DoTxIdempotent(t *testing.T) {
+	undetermined := ydberr.NewOperationError(Ydb.StatusIds_UNDETERMINED, nil)
+
+	// commit result is unknown, so transaction is not repeated by default
+	fs := &fakeSession{commitErrs: []error{undetermined}}
+	var runs int
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	})
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_UNDETERMINED))
+	assert.Equal(t, 1, runs)
+
+	fs = &fakeSession{commitErrs: []error{undetermined}}
+	runs = 0
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	}, WithTxIdempotent()))
+	assert.Equal(t, 2, runs)
+}
+
+func TestCtx_DoTxQueryStatus(t *testing.T) {
+	// op ignores query status error, but transaction is retried and not committed
+	fs := &fakeSession{statuses: []Ydb.StatusIds_StatusCode{Ydb.StatusIds_ABORTED}}
+	var runs int
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		return selectOne(ctx, tx)
+	}))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, []string{"exec select 1", "exec select 1", "commit tx2"}, fs.calls)
+	assert.Equal(t, 2, fs.released)
+
+	// not retryable status is returned
+	fs = &fakeSession{statuses: []Ydb.StatusIds_StatusCode{Ydb.StatusIds_GENERIC_ERROR}}
+	err := newTestCtx(fs).DoTx(context.Background(), selectOne)
+	require.ErrorIs(t, err, ErrPartStatus)
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_GENERIC_ERROR))
+	assert.Equal(t, []string{"exec select 1"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxInlineCommitStatus(t *testing.T) {
+	fs := &fakeSession{statuses: []Ydb.StatusIds_StatusCode{Ydb.StatusIds_ABORTED}}
+	var runs int
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		runs++
+		_, err := tx.Query("select 1").Commit().Exec(ctx)
+		return err
+	}))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, []string{"exec select 1", "exec select 1"}, fs.calls)
+	assert.Equal(t, 2, fs.released)
+
+	fs = &fakeSession{statuses: []Ydb.StatusIds_StatusCode{Ydb.StatusIds_ABORTED, Ydb.StatusIds_ABORTED}}
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		_, err := tx.Query("select 1").Commit().Exec(ctx)
+		return err
+	}, WithTxMaxAttempts(2))
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_ABORTED))
+	assert.Equal(t, 2, fs.released)
+}
+
+func TestCtx_DoTxInlineCommitStream(t *testing.T) {
+	aborted := []*Ydb_Query.ExecuteQueryResponsePart{
+		testParts()[0],
+		{Status: Ydb.StatusIds_ABORTED},
+	}
+
+	// stream left unread by op is drained to get commit result
+	fs := &fakeSession{parts: aborted}
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		_, err := tx.Query("select stream").Commit().Stream(ctx)
+		return err
+	}, WithTxMaxAttempts(1))
+	require.True(t, ydberr.IsStatus(err, Ydb.StatusIds_ABORTED))
+	assert.Equal(t, []string{"exec select stream"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+
+	fs = &fakeSession{parts: testParts()}
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		_, err := tx.Query("select stream").Commit().Stream(ctx)
+		return err
+	}))
+	assert.Equal(t, []string{"exec select stream"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+
+	// commit stream closed before its end leaves commit result unknown
+	fs = &fakeSession{parts: testParts()}
+	err = newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		rs, err := tx.Query("select stream").Commit().Stream(ctx)
+		if err != nil {
+			return err
+		}
+		rs.Close()
+		return nil
+	})
+	require.ErrorIs(t, err, ErrTxCommitUnknown)
+	assert.Equal(t, []string{"exec select stream"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestCtx_DoTxInlineCommitOption(t *testing.T) {
+	fs := &fakeSession{}
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), selectOne, WithTxInlineCommit()))
+
+	// no separate commit
+	assert.Equal(t, []string{"exec select 1"}, fs.calls)
+	assert.True(t, fs.txControls[0].GetCommitTx())
+	assert.IsType(t, &Ydb_Query.TransactionControl_BeginTx{}, fs.txControls[0].GetTxSelector())
+	assert.Equal(t, 1, fs.released)
+
+	// transaction is finished with the first query
+	fs = &fakeSession{}
+	err := newTestCtx(fs).DoTx(context.Background(), func(ctx context.Context, tx *Transaction) error {
+		if err := selectOne(ctx, tx); err != nil {
+			return err
+		}
+		_, err := tx.Query("select 2").Exec(ctx)
+		return err
+	}, WithTxInlineCommit())
+	require.ErrorIs(t, err, ErrTxFinished)
+	assert.Equal(t, []string{"exec select 1"}, fs.calls)
+
+	// aborted inline commit is retried
+	fs = &fakeSession{statuses: []Ydb.StatusIds_StatusCode{Ydb.StatusIds_ABORTED}}
+	require.NoError(t, newTestCtx(fs).DoTx(context.Background(), selectOne, WithTxInlineCommit()))
+	assert.Equal(t, []string{"exec select 1", "exec select 1"}, fs.calls)
+	assert.Equal(t, 2, fs.released)
+}
@@ -1,7 +1,34 @@
 package query
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+)
 
 var (
 	ErrResult = errors.New("result fetch error")
 )
+
+// issuesError is returned by Result.Err() and ResultStream.Err().
+// It renders query issues with %+v.
+type issuesError struct {
+	err    error
+	query  string
+	issues []*Ydb_Issue.IssueMessage
+}
+
+func (e *issuesError) Error() string {
+	return e.err.Error()
+}
+
+func (e *issuesError) Unwrap() error {
+	return e.err
+}
+
+func (e *issuesError) Format(f fmt.State, verb rune) {
+	ydberr.FormatError(f, verb, e, e.issues, e.query)
+}
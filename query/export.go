@@ -0,0 +1,400 @@
+package query
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/adwski/ydb-go-query/internal/jsonvalue"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const (
+	// BytesBase64 exports String and Yson values in standard base64 encoding.
+	BytesBase64 BytesFormat = iota
+	// BytesHex exports String and Yson values in hex encoding.
+	BytesHex
+	// BytesText exports String and Yson values as is.
+	BytesText
+)
+
+var (
+	ErrExport = errors.New("cannot export result")
+)
+
+type (
+	// BytesFormat defines how String and Yson values are exported.
+	BytesFormat int
+
+	// ExportOptions configures formatting of exported values.
+	// Zero value is usable.
+	ExportOptions struct {
+		// Null is a text of NULL values, it is empty by default. It is used only with CSV,
+		// JSON has its own null.
+		Null string
+
+		// TimeLayout is a layout of date and time values, time.RFC3339Nano by default.
+		TimeLayout string
+
+		// Bytes is a format of String and Yson values, BytesBase64 by default.
+		Bytes BytesFormat
+
+		// Comma is a CSV field delimiter, ',' by default.
+		Comma rune
+
+		// NoHeader disables CSV header with column names.
+		NoHeader bool
+	}
+
+	// exporter converts rows of result set to CSV records and JSON objects.
+	exporter struct {
+		opts *ExportOptions
+		cols []*Ydb.Column
+
+		// names holds JSON encoded column names
+		names [][]byte
+
+		// rows counts rows exported from stream
+		rows int
+	}
+)
+
+func newExporter(cols []*Ydb.Column, opts *ExportOptions) *exporter {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	return &exporter{
+		opts: opts,
+		cols: cols,
+	}
+}
+
+// WriteCSV writes rows of result set to w in CSV format.
+// Values of Optional types are exported as values of their items or ExportOptions.Null,
+// containers are exported as JSON. Header with column names is written first
+// unless ExportOptions.NoHeader is set.
+func (rs *ResultSet) WriteCSV(w io.Writer, opts ExportOptions) error {
+	cw := newCSVWriter(w, &opts)
+	exp := newExporter(rs.cols, &opts)
+	if err := exp.writeHeader(cw); err != nil {
+		return err
+	}
+	for idx, row := range rs.rows {
+		if err := exp.writeRecord(cw, idx, row); err != nil {
+			return err
+		}
+	}
+
+	return flushCSV(cw)
+}
+
+// WriteJSONLines writes rows of result set to w as JSON objects separated by newlines.
+// Object keys are column names in order of columns. Values are exported with default ExportOptions:
+// NULL is null, date and time values are strings in RFC 3339 format, intervals are
+// Go duration strings, String values are base64 encoded, Decimal and Uuid values are strings.
+func (rs *ResultSet) WriteJSONLines(w io.Writer) error {
+	exp := newExporter(rs.cols, nil)
+
+	var buf bytes.Buffer
+	for idx, row := range rs.rows {
+		buf.Reset()
+		if err := exp.writeLine(w, &buf, idx, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON returns rows of result set as JSON array of objects.
+// Values are exported the same way as with WriteJSONLines.
+func (rs *ResultSet) MarshalJSON() ([]byte, error) {
+	exp := newExporter(rs.cols, nil)
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for idx, row := range rs.rows {
+		if idx > 0 {
+			buf.WriteByte(',')
+		}
+		if err := exp.writeObject(&buf, idx, row); err != nil {
+			return nil, err
+		}
+	}
+	buf.WriteByte(']')
+
+	return buf.Bytes(), nil
+}
+
+// Maps returns rows of result set as maps of column names to Go values.
+// Values are decoded the same way as when they are scanned into *any.
+// Values which cannot be decoded are set to nil.
+func (rs *ResultSet) Maps() []map[string]any {
+	maps := make([]map[string]any, 0, len(rs.rows))
+	for _, row := range rs.rows {
+		m := make(map[string]any, len(rs.cols))
+		items := row.GetItems()
+		for i, col := range rs.cols {
+			if i >= len(items) {
+				m[col.Name] = nil
+				continue
+			}
+			m[col.Name], _ = goValue(col.Type, items[i])
+		}
+		maps = append(maps, m)
+	}
+
+	return maps
+}
+
+// WriteCSV writes rows of the first result set to w in CSV format.
+// See ResultSet.WriteCSV.
+func (r *Result) WriteCSV(w io.Writer, opts ExportOptions) error {
+	return r.firstOrEmpty().WriteCSV(w, opts)
+}
+
+// WriteJSONLines writes rows of the first result set to w as JSON objects separated by newlines.
+// See ResultSet.WriteJSONLines.
+func (r *Result) WriteJSONLines(w io.Writer) error {
+	return r.firstOrEmpty().WriteJSONLines(w)
+}
+
+// MarshalJSON returns rows of the first result set as JSON array of objects.
+// See ResultSet.MarshalJSON.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	return r.firstOrEmpty().MarshalJSON()
+}
+
+// Maps returns rows of the first result set as maps of column names to Go values.
+// See ResultSet.Maps.
+func (r *Result) Maps() []map[string]any {
+	return r.firstOrEmpty().Maps()
+}
+
+func (r *Result) firstOrEmpty() *ResultSet {
+	if first := r.first(); first != nil {
+		return first
+	}
+
+	return &ResultSet{}
+}
+
+// WriteCSV reads the rest of stream and writes rows of all result sets to w in CSV format,
+// rows are written as soon as they are received. Header is written
+// before the first row of every result set unless ExportOptions.NoHeader is set,
+// so result sets with interleaved parts should not be exported with the same stream.
+// See ResultSet.WriteCSV.
+func (rs *ResultStream) WriteCSV(w io.Writer, opts ExportOptions) error {
+	cw := newCSVWriter(w, &opts)
+	exporters := make(map[int64]*exporter)
+	for part, err := range rs.Parts() {
+		if err != nil {
+			return errors.Join(ErrExport, err)
+		}
+		exp, ok := exporters[part.Index]
+		if !ok {
+			exp = newExporter(part.Cols, &opts)
+			exporters[part.Index] = exp
+			if err = exp.writeHeader(cw); err != nil {
+				return err
+			}
+		}
+		for _, row := range part.ResultSet.Rows {
+			if err = exp.writeRecord(cw, exp.rows, row); err != nil {
+				return err
+			}
+			exp.rows++
+		}
+		// part is passed to w before next one is requested
+		if err = flushCSV(cw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSONLines reads the rest of stream and writes rows of all result sets to w
+// as JSON objects separated by newlines, rows are written as soon as they are received.
+// See ResultSet.WriteJSONLines.
+func (rs *ResultStream) WriteJSONLines(w io.Writer) error {
+	var buf bytes.Buffer
+	exporters := make(map[int64]*exporter)
+	for part, err := range rs.Parts() {
+		if err != nil {
+			return errors.Join(ErrExport, err)
+		}
+		exp, ok := exporters[part.Index]
+		if !ok {
+			exp = newExporter(part.Cols, nil)
+			exporters[part.Index] = exp
+		}
+		for _, row := range part.ResultSet.Rows {
+			buf.Reset()
+			if err = exp.writeLine(w, &buf, exp.rows, row); err != nil {
+				return err
+			}
+			exp.rows++
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) writeHeader(cw *csv.Writer) error {
+	if e.opts.NoHeader {
+		return nil
+	}
+
+	header := make([]string, len(e.cols))
+	for i, col := range e.cols {
+		header[i] = col.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return errors.Join(ErrExport, err)
+	}
+
+	return nil
+}
+
+func (e *exporter) writeRecord(cw *csv.Writer, idx int, row *Ydb.Value) error {
+	if err := NewRow(e.cols, row).validate(); err != nil {
+		return fmt.Errorf("%w: row %d: %w", ErrExport, idx, err)
+	}
+
+	record := make([]string, len(e.cols))
+	for i, item := range row.GetItems() {
+		v, err := e.value(i, item)
+		if err != nil {
+			return fmt.Errorf("%w: row %d: %w", ErrExport, idx, err)
+		}
+		if record[i], err = e.cell(v); err != nil {
+			return fmt.Errorf("%w: row %d: column %q: %w", ErrExport, idx, e.cols[i].Name, err)
+		}
+	}
+	if err := cw.Write(record); err != nil {
+		return errors.Join(ErrExport, err)
+	}
+
+	return nil
+}
+
+// writeLine writes JSON object of row followed by newline to w, buf is used for encoding.
+func (e *exporter) writeLine(w io.Writer, buf *bytes.Buffer, idx int, row *Ydb.Value) error {
+	if err := e.writeObject(buf, idx, row); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return errors.Join(ErrExport, err)
+	}
+
+	return nil
+}
+
+// writeObject writes row as JSON object with keys in order of columns.
+func (e *exporter) writeObject(buf *bytes.Buffer, idx int, row *Ydb.Value) error {
+	if err := NewRow(e.cols, row).validate(); err != nil {
+		return fmt.Errorf("%w: row %d: %w", ErrExport, idx, err)
+	}
+	if e.names == nil {
+		e.names = make([][]byte, len(e.cols))
+		for i, col := range e.cols {
+			e.names[i], _ = json.Marshal(col.Name) //nolint:errchkjson // string is always encoded
+		}
+	}
+
+	buf.WriteByte('{')
+	for i, item := range row.GetItems() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		v, err := e.value(i, item)
+		if err != nil {
+			return fmt.Errorf("%w: row %d: %w", ErrExport, idx, err)
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%w: row %d: column %q: %w", ErrExport, idx, e.cols[i].Name, err)
+		}
+		buf.Write(e.names[i])
+		buf.WriteByte(':')
+		buf.Write(b)
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+// value decodes value of column i and converts it to JSON compatible form.
+func (e *exporter) value(i int, val *Ydb.Value) (any, error) {
+	v, err := goValue(e.cols[i].Type, val)
+	if err != nil {
+		return nil, fmt.Errorf("column %q: %w", e.cols[i].Name, err)
+	}
+
+	return e.export(v), nil
+}
+
+// export converts decoded value to form which can be encoded to JSON.
+func (e *exporter) export(v any) any {
+	return jsonvalue.Convert(v, &jsonvalue.Options{
+		EncodeBytes: e.encodeBytes,
+		TimeLayout:  e.opts.TimeLayout,
+	})
+}
+
+// encodeBytes encodes String and Yson values according to options.
+func (e *exporter) encodeBytes(b []byte) string {
+	switch e.opts.Bytes {
+	case BytesHex:
+		return hex.EncodeToString(b)
+	case BytesText:
+		return string(b)
+	default:
+		return base64.StdEncoding.EncodeToString(b)
+	}
+}
+
+// cell returns CSV field of exported value. Strings are used as is,
+// other values are encoded to JSON.
+func (e *exporter) cell(v any) (string, error) {
+	switch tv := v.(type) {
+	case nil:
+		return e.opts.Null, nil
+	case string:
+		return tv, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return string(b), nil
+}
+
+func newCSVWriter(w io.Writer, opts *ExportOptions) *csv.Writer {
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+
+	return cw
+}
+
+func flushCSV(cw *csv.Writer) error {
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return errors.Join(ErrExport, err)
+	}
+
+	return nil
+}
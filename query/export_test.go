@@ -0,0 +1,130 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func exportTestSet(t *testing.T) *ResultSet {
+	t.Helper()
+
+	ts, err := types.Timestamp(time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	tags, err := types.List(types.UTF8("a"), types.UTF8("b,c"))
+	require.NoError(t, err)
+	name := types.Optional(types.UTF8("alice"))
+	null := types.NullOf(types.UTF8("").Type)
+
+	row := func(id uint64, name *Ydb.TypedValue) *Ydb.Value {
+		return &Ydb.Value{Items: []*Ydb.Value{
+			types.Uint64(id).Value, name.Value, types.Bytes([]byte("hi")).Value, ts.Value, tags.Value,
+		}}
+	}
+
+	return &ResultSet{
+		cols: []*Ydb.Column{
+			{Name: "id", Type: types.Uint64(0).Type},
+			{Name: "name", Type: name.Type},
+			{Name: "data", Type: types.Bytes(nil).Type},
+			{Name: "ts", Type: ts.Type},
+			{Name: "tags", Type: tags.Type},
+		},
+		rows: []*Ydb.Value{row(1, name), row(2, null)},
+	}
+}
+
+func TestResultSet_WriteCSV(t *testing.T) {
+	set := exportTestSet(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, set.WriteCSV(&buf, ExportOptions{}))
+	assert.Equal(t, "id,name,data,ts,tags\n"+
+		"1,alice,aGk=,2024-09-20T12:00:00Z,\"[\"\"a\"\",\"\"b,c\"\"]\"\n"+
+		"2,,aGk=,2024-09-20T12:00:00Z,\"[\"\"a\"\",\"\"b,c\"\"]\"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, set.WriteCSV(&buf, ExportOptions{
+		Null:       "NULL",
+		TimeLayout: time.DateOnly,
+		Bytes:      BytesText,
+		Comma:      ';',
+		NoHeader:   true,
+	}))
+	assert.Equal(t, "1;alice;hi;2024-09-20;\"[\"\"a\"\",\"\"b,c\"\"]\"\n"+
+		"2;NULL;hi;2024-09-20;\"[\"\"a\"\",\"\"b,c\"\"]\"\n", buf.String())
+}
+
+func TestResultSet_WriteJSONLines(t *testing.T) {
+	set := exportTestSet(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, set.WriteJSONLines(&buf))
+	assert.Equal(t,
+		`{"id":1,"name":"alice","data":"aGk=","ts":"2024-09-20T12:00:00Z","tags":["a","b,c"]}`+"\n"+
+			`{"id":2,"name":null,"data":"aGk=","ts":"2024-09-20T12:00:00Z","tags":["a","b,c"]}`+"\n",
+		buf.String())
+
+	b, err := json.Marshal(&Result{sets: resultSets{set}})
+	require.NoError(t, err)
+	assert.Equal(t, `[{"id":1,"name":"alice","data":"aGk=","ts":"2024-09-20T12:00:00Z","tags":["a","b,c"]},`+
+		`{"id":2,"name":null,"data":"aGk=","ts":"2024-09-20T12:00:00Z","tags":["a","b,c"]}]`, string(b))
+
+	b, err = json.Marshal(&Result{})
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(b))
+}
+
+func TestResultSet_Maps(t *testing.T) {
+	set := exportTestSet(t)
+
+	maps := (&Result{sets: resultSets{set}}).Maps()
+	require.Len(t, maps, 2)
+	assert.Equal(t, map[string]any{
+		"id":   uint64(1),
+		"name": "alice",
+		"data": []byte("hi"),
+		"ts":   time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC),
+		"tags": []any{"a", "b,c"},
+	}, maps[0])
+	assert.Nil(t, maps[1]["name"])
+}
+
+func TestResultSet_ExportError(t *testing.T) {
+	set := &ResultSet{
+		cols: []*Ydb.Column{uint64Col("id")},
+		rows: []*Ydb.Value{uint64Row(1), {Items: []*Ydb.Value{types.UTF8("x").Value}}},
+	}
+
+	var buf bytes.Buffer
+	err := set.WriteCSV(&buf, ExportOptions{})
+	require.ErrorIs(t, err, ErrExport)
+	require.ErrorIs(t, err, ErrScanType)
+	assert.Contains(t, err.Error(), `row 1: column "id"`)
+
+	err = set.WriteJSONLines(&buf)
+	require.ErrorIs(t, err, ErrExport)
+}
+
+func TestResultStream_Export(t *testing.T) {
+	rs, _ := newTestStream(&fakeStream{parts: testParts()})
+	require.NoError(t, rs.prefetch())
+
+	var buf bytes.Buffer
+	require.NoError(t, rs.WriteCSV(&buf, ExportOptions{}))
+	assert.Equal(t, "id\n1\n2\n3\n", buf.String())
+
+	rs, _ = newTestStream(&fakeStream{parts: interleavedParts()})
+	require.NoError(t, rs.prefetch())
+
+	buf.Reset()
+	require.NoError(t, rs.WriteJSONLines(&buf))
+	assert.Equal(t, `{"cnt":100}`+"\n"+`{"id":1,"val":10}`+"\n"+`{"cnt":200}`+"\n"+`{"id":2,"val":20}`+"\n", buf.String())
+}
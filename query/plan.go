@@ -0,0 +1,289 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	opTableFullScan    = "TableFullScan"
+	opTableRangeScan   = "TableRangeScan"
+	opTablePointLookup = "TablePointLookup"
+	opTableLookup      = "TableLookup"
+
+	accessFullScan = "FullScan"
+)
+
+var (
+	ErrPlan = errors.New("cannot parse query plan")
+)
+
+type (
+	// Plan is a parsed query execution plan.
+	Plan struct {
+		// Root is the root node of operators tree.
+		Root *PlanNode
+
+		// JSON is the original plan provided by YDB.
+		JSON string
+
+		// AST is the query AST provided by YDB.
+		AST string
+
+		// Tables describes access to tables used by query.
+		Tables []PlanTable
+	}
+
+	// PlanNode is a node of plan tree.
+	PlanNode struct {
+		// Props holds all node properties as they appear in plan.
+		Props map[string]any
+
+		// Type is the node type, for example "ResultSet" or "Limit-TableFullScan".
+		Type string
+
+		Operators []PlanOperator
+
+		// Tables which are accessed by node operators.
+		Tables []string
+
+		Children []*PlanNode
+
+		ID int
+	}
+
+	// PlanOperator is a single operator of plan node.
+	PlanOperator struct {
+		// Props holds all operator properties as they appear in plan.
+		Props map[string]any
+
+		// Name is the operator name, for example "TableFullScan" or "Filter".
+		Name string
+
+		// Table is the table operator reads from, if any.
+		Table string
+	}
+
+	// PlanTable describes access to particular table.
+	PlanTable struct {
+		Name   string
+		Reads  []PlanAccess
+		Writes []PlanAccess
+	}
+
+	// PlanAccess describes single table read or write.
+	PlanAccess struct {
+		// Type is the access type, for example "FullScan", "Scan", "Lookup" or "MultiLookup".
+		Type     string
+		ScanBy   []string
+		LookupBy []string
+		Columns  []string
+	}
+
+	planNodeJSON struct {
+		Type      string            `json:"Node Type"`
+		Operators []map[string]any  `json:"Operators"`
+		Tables    []string          `json:"Tables"`
+		Plans     []json.RawMessage `json:"Plans"`
+		ID        int               `json:"PlanNodeId"`
+	}
+
+	planJSON struct {
+		Plan   json.RawMessage `json:"Plan"`
+		Tables []struct {
+			Name   string       `json:"name"`
+			Reads  []accessJSON `json:"reads"`
+			Writes []accessJSON `json:"writes"`
+		} `json:"tables"`
+	}
+
+	accessJSON struct {
+		Type     string   `json:"type"`
+		ScanBy   []string `json:"scan_by"`
+		LookupBy []string `json:"lookup_by"`
+		Columns  []string `json:"columns"`
+	}
+)
+
+// ParsePlan parses query plan in JSON format, as it is returned by YDB in query stats.
+func ParsePlan(queryPlan, ast string) (*Plan, error) {
+	plan := &Plan{
+		JSON: queryPlan,
+		AST:  ast,
+	}
+	if queryPlan == "" {
+		return plan, nil
+	}
+
+	var pj planJSON
+	if err := json.Unmarshal([]byte(queryPlan), &pj); err != nil {
+		return nil, errors.Join(ErrPlan, err)
+	}
+
+	for _, t := range pj.Tables {
+		plan.Tables = append(plan.Tables, PlanTable{
+			Name:   t.Name,
+			Reads:  planAccess(t.Reads),
+			Writes: planAccess(t.Writes),
+		})
+	}
+
+	if len(pj.Plan) > 0 {
+		root, err := parsePlanNode(pj.Plan)
+		if err != nil {
+			return nil, errors.Join(ErrPlan, err)
+		}
+		plan.Root = root
+	}
+
+	return plan, nil
+}
+
+func planAccess(aa []accessJSON) []PlanAccess {
+	res := make([]PlanAccess, 0, len(aa))
+	for _, a := range aa {
+		res = append(res, PlanAccess(a))
+	}
+
+	return res
+}
+
+func parsePlanNode(raw json.RawMessage) (*PlanNode, error) {
+	var nj planNodeJSON
+	if err := json.Unmarshal(raw, &nj); err != nil {
+		return nil, fmt.Errorf("plan node: %w", err)
+	}
+
+	node := &PlanNode{
+		Type:   nj.Type,
+		ID:     nj.ID,
+		Tables: nj.Tables,
+	}
+	if err := json.Unmarshal(raw, &node.Props); err != nil {
+		return nil, fmt.Errorf("plan node: %w", err)
+	}
+	delete(node.Props, "Plans")
+
+	for _, op := range nj.Operators {
+		name, _ := op["Name"].(string)
+		table, _ := op["Table"].(string)
+		node.Operators = append(node.Operators, PlanOperator{
+			Name:  name,
+			Table: table,
+			Props: op,
+		})
+	}
+
+	for _, childRaw := range nj.Plans {
+		child, err := parsePlanNode(childRaw)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	return node, nil
+}
+
+// Walk calls fn for every node of plan tree in depth-first order.
+// Children of node are not visited if fn returns false.
+func (p *Plan) Walk(fn func(node *PlanNode) bool) {
+	if p.Root != nil {
+		p.Root.walk(fn)
+	}
+}
+
+func (n *PlanNode) walk(fn func(node *PlanNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.walk(fn)
+	}
+}
+
+// Operators returns all operators of plan tree.
+func (p *Plan) Operators() []PlanOperator {
+	var ops []PlanOperator
+	p.Walk(func(node *PlanNode) bool {
+		ops = append(ops, node.Operators...)
+		return true
+	})
+
+	return ops
+}
+
+// Table returns access description of table. Table can be specified
+// by its name or full path.
+func (p *Plan) Table(table string) (PlanTable, bool) {
+	for _, t := range p.Tables {
+		if tableMatches(t.Name, table) {
+			return t, true
+		}
+	}
+
+	return PlanTable{}, false
+}
+
+// HasFullScan reports whether query reads the whole table.
+// Table can be specified by its name or full path.
+func (p *Plan) HasFullScan(table string) bool {
+	if t, ok := p.Table(table); ok {
+		for _, r := range t.Reads {
+			if r.Type == accessFullScan {
+				return true
+			}
+		}
+	}
+
+	for _, op := range p.Operators() {
+		if op.IsFullScan() && tableMatches(op.Table, table) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasLookup reports whether query reads table by key,
+// either with point lookup or with key range scan.
+func (p *Plan) HasLookup(table string) bool {
+	for _, op := range p.Operators() {
+		if op.IsLookup() && tableMatches(op.Table, table) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsFullScan reports whether operator reads the whole table.
+func (op PlanOperator) IsFullScan() bool {
+	return op.Name == opTableFullScan
+}
+
+// IsLookup reports whether operator reads table by key or key range.
+func (op PlanOperator) IsLookup() bool {
+	switch op.Name {
+	case opTablePointLookup, opTableLookup, opTableRangeScan:
+		return true
+	default:
+		return false
+	}
+}
+
+// tableMatches compares table name from plan with provided one.
+// Plan may contain either full table path or path relative to database.
+func tableMatches(name, table string) bool {
+	if name == "" || table == "" {
+		return false
+	}
+
+	name, table = strings.TrimPrefix(name, "/"), strings.TrimPrefix(table, "/")
+
+	return name == table ||
+		strings.HasSuffix(name, "/"+table) ||
+		strings.HasSuffix(table, "/"+name)
+}
@@ -0,0 +1,129 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+const testPlan = `{
+  "meta": {"version": "0.2", "type": "query"},
+  "tables": [
+    {"name": "/local/users", "reads": [{"type": "FullScan", "scan_by": ["id"], "columns": ["id", "name"]}]},
+    {"name": "/local/orders", "reads": [{"type": "Lookup", "lookup_by": ["id"], "columns": ["id"]}]}
+  ],
+  "Plan": {
+    "Node Type": "Query",
+    "PlanNodeType": "Query",
+    "Plans": [{
+      "Node Type": "ResultSet",
+      "PlanNodeId": 3,
+      "Plans": [
+        {
+          "Node Type": "Limit-TableFullScan",
+          "PlanNodeId": 2,
+          "Operators": [
+            {"Name": "Limit", "Limit": "10"},
+            {"Name": "TableFullScan", "Table": "users", "ReadColumns": ["id", "name"]}
+          ],
+          "Tables": ["users"]
+        },
+        {
+          "Node Type": "TablePointLookup",
+          "PlanNodeId": 1,
+          "Operators": [{"Name": "TablePointLookup", "Table": "orders"}],
+          "Tables": ["orders"]
+        }
+      ]
+    }]
+  }
+}`
+
+func TestParsePlan(t *testing.T) {
+	plan, err := ParsePlan(testPlan, "(ast)")
+	require.NoError(t, err)
+
+	assert.Equal(t, "(ast)", plan.AST)
+	require.NotNil(t, plan.Root)
+	assert.Equal(t, "Query", plan.Root.Type)
+	require.Len(t, plan.Root.Children, 1)
+
+	scan := plan.Root.Children[0].Children[0]
+	assert.Equal(t, "Limit-TableFullScan", scan.Type)
+	assert.Equal(t, 2, scan.ID)
+	assert.Equal(t, []string{"users"}, scan.Tables)
+	require.Len(t, scan.Operators, 2)
+	assert.Equal(t, "10", scan.Operators[0].Props["Limit"])
+	assert.True(t, scan.Operators[1].IsFullScan())
+	assert.NotContains(t, scan.Props, "Plans")
+
+	var types []string
+	plan.Walk(func(node *PlanNode) bool {
+		types = append(types, node.Type)
+		return node.Type != "ResultSet"
+	})
+	assert.Equal(t, []string{"Query", "ResultSet"}, types)
+	assert.Len(t, plan.Operators(), 3)
+
+	assert.True(t, plan.HasFullScan("users"))
+	assert.True(t, plan.HasFullScan("/local/users"))
+	assert.False(t, plan.HasFullScan("orders"))
+	assert.False(t, plan.HasFullScan("user"))
+	assert.True(t, plan.HasLookup("orders"))
+	assert.False(t, plan.HasLookup("users"))
+
+	orders, ok := plan.Table("orders")
+	require.True(t, ok)
+	assert.Equal(t, []PlanAccess{{Type: "Lookup", LookupBy: []string{"id"}, Columns: []string{"id"}}}, orders.Reads)
+
+	_, err = ParsePlan("{", "")
+	require.ErrorIs(t, err, ErrPlan)
+
+	plan, err = ParsePlan("", "")
+	require.NoError(t, err)
+	assert.False(t, plan.HasFullScan("users"))
+}
+
+func TestQuery_Explain(t *testing.T) {
+	var modes []Ydb_Query.ExecMode
+	q := newQuery("SELECT * FROM users LIMIT 10", func(_ context.Context, q *Query) (*Result, error) {
+		modes = append(modes, q.execMode)
+		res, _ := newTestResult([]*Ydb_Query.ExecuteQueryResponsePart{{
+			Status:    Ydb.StatusIds_SUCCESS,
+			ExecStats: &Ydb_TableStats.QueryStats{QueryPlan: testPlan},
+		}}, nil)
+
+		return res, res.recv()
+	}, nil)
+
+	plan, err := q.Explain(context.Background())
+	require.NoError(t, err)
+	assert.True(t, plan.HasFullScan("users"))
+
+	require.NoError(t, q.Validate(context.Background()))
+	require.NoError(t, q.Parse(context.Background()))
+
+	assert.Equal(t, []Ydb_Query.ExecMode{
+		Ydb_Query.ExecMode_EXEC_MODE_EXPLAIN,
+		Ydb_Query.ExecMode_EXEC_MODE_VALIDATE,
+		Ydb_Query.ExecMode_EXEC_MODE_PARSE,
+	}, modes)
+	assert.Equal(t, Ydb_Query.ExecMode_EXEC_MODE_UNSPECIFIED, q.execMode)
+}
+
+func TestQuery_ValidateError(t *testing.T) {
+	q := newQuery("SELECT * FROM unknown", func(context.Context, *Query) (*Result, error) {
+		res, _ := newTestResult([]*Ydb_Query.ExecuteQueryResponsePart{{
+			Status: Ydb.StatusIds_SCHEME_ERROR,
+		}}, nil)
+
+		return res, res.recv()
+	}, nil)
+
+	require.ErrorIs(t, q.Validate(context.Background()), ErrPartStatus)
+}
@@ -2,33 +2,40 @@ package query
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
 )
 
 type (
-	execFunc func(
-		context.Context,
-		string,
-		map[string]*Ydb.TypedValue,
-		func([]*Ydb.Value) error,
-		time.Duration,
-	) (*Result, error)
+	execFunc   func(context.Context, *Query) (*Result, error)
+	streamFunc func(context.Context, *Query) (*ResultStream, error)
 
 	Query struct {
-		collectRowsFunc func([]*Ydb.Value) error
+		collectRowsFunc CollectFunc
 		execFunc        execFunc
+		streamFunc      streamFunc
 		params          map[string]*Ydb.TypedValue
 		content         string
 		timeout         time.Duration
+		idempotent      bool
+		autoDeclare     bool
+		args            goArgs
+		statsMode       Ydb_Query.StatsMode
+		execMode        Ydb_Query.ExecMode
+		syntax          Ydb_Query.Syntax
+
+		concurrentResultSets bool
 	}
 )
 
-func newQuery(content string, eF execFunc) *Query {
+func newQuery(content string, eF execFunc, sF streamFunc) *Query {
 	return &Query{
-		content:  content,
-		execFunc: eF,
+		content:    content,
+		execFunc:   eF,
+		streamFunc: sF,
 	}
 }
 
@@ -47,18 +54,166 @@ func (q *Query) Param(name string, val *Ydb.TypedValue) *Query {
 	return q
 }
 
-func (q *Query) Collect(collectRowsFunc func([]*Ydb.Value) error) *Query {
+// Collect sets func which is called every time result part is received.
+// Result rows are not accumulated in this case.
+func (q *Query) Collect(collectRowsFunc CollectFunc) *Query {
 	q.collectRowsFunc = collectRowsFunc
 
 	return q
 }
 
+// ConcurrentResultSets allows YDB to send parts of different
+// result sets concurrently. Parts are distributed among result sets by their indexes.
+func (q *Query) ConcurrentResultSets() *Query {
+	q.concurrentResultSets = true
+
+	return q
+}
+
 func (q *Query) Timeout(timeout time.Duration) *Query {
 	q.timeout = timeout
 
 	return q
 }
 
+// Idempotent marks query as idempotent. Such query is also retried
+// on errors which leave it in undetermined state (for example transport errors).
+func (q *Query) Idempotent() *Query {
+	q.idempotent = true
+
+	return q
+}
+
+// AutoDeclare enables or disables generation of DECLARE statements
+// for query parameters. By default, it is inherited from query context.
+func (q *Query) AutoDeclare(enable bool) *Query {
+	q.autoDeclare = enable
+
+	return q
+}
+
+// Stats sets query stats collection mode. By default, it is inherited from query context.
+func (q *Query) Stats(mode Ydb_Query.StatsMode) *Query {
+	q.statsMode = mode
+
+	return q
+}
+
+// Syntax sets query syntax. By default, it is inherited from query context.
+// AutoDeclare has no effect for PostgreSQL syntax.
+func (q *Query) Syntax(syntax Ydb_Query.Syntax) *Query {
+	q.syntax = syntax
+
+	return q
+}
+
+// Args sets positional query arguments which are passed as $p1, $p2, ... parameters.
+// Parameter types are inferred from Go values with types.FromGo,
+// or with types.PgFromGo if query has PostgreSQL syntax.
+func (q *Query) Args(args ...any) *Query {
+	q.args.positional = args
+
+	return q
+}
+
+// Arg sets query parameter from Go value, its type is inferred with types.FromGo,
+// or with types.PgFromGo if query has PostgreSQL syntax.
+// Conversion errors are returned on query execution.
+func (q *Query) Arg(name string, val any) *Query {
+	q.args.set(name, val)
+
+	return q
+}
+
+// ArgsFromStruct sets query parameters from exported fields of struct with types.ParamsFromStruct.
+// Field `ydb:"name,type=Datetime"` tags define parameter names and types.
+// It is not supported with PostgreSQL syntax.
+func (q *Query) ArgsFromStruct(v any) *Query {
+	q.args.structs = append(q.args.structs, v)
+
+	return q
+}
+
 func (q *Query) Exec(ctx context.Context) (*Result, error) {
-	return q.execFunc(ctx, q.content, q.params, q.collectRowsFunc, q.timeout)
+	return q.execFunc(ctx, q)
+}
+
+// Explain builds query execution plan without executing query.
+func (q *Query) Explain(ctx context.Context) (*Plan, error) {
+	res, err := q.execIn(ctx, Ydb_Query.ExecMode_EXEC_MODE_EXPLAIN)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePlan(res.Stats().GetQueryPlan(), res.Stats().GetQueryAst())
+}
+
+// Validate checks query syntax and semantics, including
+// existence of tables and columns, without executing query.
+func (q *Query) Validate(ctx context.Context) error {
+	_, err := q.execIn(ctx, Ydb_Query.ExecMode_EXEC_MODE_VALIDATE)
+
+	return err
+}
+
+// Parse checks query syntax without executing query.
+func (q *Query) Parse(ctx context.Context) error {
+	_, err := q.execIn(ctx, Ydb_Query.ExecMode_EXEC_MODE_PARSE)
+
+	return err
+}
+
+// execIn runs copy of query in provided exec mode.
+// Unsuccessful query status is returned as error, warnings are ignored.
+func (q *Query) execIn(ctx context.Context, mode Ydb_Query.ExecMode) (*Result, error) {
+	mq := *q
+	mq.execMode = mode
+	mq.collectRowsFunc = nil
+	// query is not executed, so it can be safely retried
+	mq.idempotent = true
+	if mode == Ydb_Query.ExecMode_EXEC_MODE_EXPLAIN && mq.statsMode == StatsModeNone {
+		// plan is delivered with stats
+		mq.statsMode = StatsModeBasic
+	}
+
+	res, err := mq.execFunc(ctx, &mq)
+	if err != nil {
+		return nil, err
+	}
+	if err = res.Err(); errors.Is(err, ErrPartStatus) {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Stream starts query execution and returns result stream
+// which allows to process query result part by part.
+// Collect func is not used with stream.
+func (q *Query) Stream(ctx context.Context) (*ResultStream, error) {
+	return q.streamFunc(ctx, q)
+}
+
+// Debug returns standalone YQL script of query, which can be run without parameters.
+// DECLARE statements are replaced with assignments of parameter values
+// rendered as YQL literals, for example $id = Uint64("1");.
+// Queries in PostgreSQL syntax are not supported.
+func (q *Query) Debug() (string, error) {
+	return debug(q.content, q.params, &q.args, q.syntax)
+}
+
+// String returns query script produced by Debug. If it cannot be rendered,
+// query content is returned as is.
+func (q *Query) String() string {
+	script, err := q.Debug()
+	if err != nil {
+		return q.content
+	}
+
+	return script
+}
+
+// render returns query content and parameters to be sent to YDB.
+func (q *Query) render() (string, map[string]*Ydb.TypedValue, error) {
+	return render(q.content, q.params, &q.args, q.syntax, q.autoDeclare)
 }
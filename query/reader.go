@@ -0,0 +1,171 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+// resultReader reads parts from result stream and accumulates
+// result metadata: status, issues, stats and tx id.
+type resultReader struct {
+	logger logger.Logger
+
+	cancel context.CancelFunc
+
+	stream Ydb_Query_V1.QueryService_ExecuteQueryClient
+
+	// onFinish is called once after stream is finished.
+	onFinish func()
+
+	stats *Ydb_TableStats.QueryStats
+
+	err error
+
+	// failure is an error which terminated query execution:
+	// unsuccessful part status or stream error.
+	failure error
+
+	txID string
+
+	// query is the text of executed query, it is used to render issue positions.
+	query string
+
+	issues []*Ydb_Issue.IssueMessage
+
+	done bool
+
+	// completed indicates that stream was read till the end,
+	// it is false if stream was closed or failed.
+	completed bool
+}
+
+func newResultReader(
+	stream Ydb_Query_V1.QueryService_ExecuteQueryClient,
+	cancel context.CancelFunc,
+	logger logger.Logger,
+) *resultReader {
+	return &resultReader{
+		logger: logger,
+		stream: stream,
+		cancel: cancel,
+	}
+}
+
+// next receives parts from stream until it gets the one with result set.
+// It returns nil part and nil error if stream is finished.
+// Unsuccessful part status also finishes the stream, status error is saved in reader.
+func (rr *resultReader) next() (*Ydb_Query.ExecuteQueryResponsePart, error) {
+	for !rr.done {
+		part, err := rr.stream.Recv()
+		rr.logger.Trace("received result part", "part", part, "error", err)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				rr.completed = true
+				rr.finish()
+
+				return nil, nil
+			}
+
+			rr.failure = errors.Join(ErrStream, err)
+			rr.finish()
+
+			return nil, rr.failure
+		}
+
+		rr.issues = append(rr.issues, part.Issues...)
+
+		if part.Status != Ydb.StatusIds_SUCCESS {
+			rr.err = errors.Join(ErrPartStatus, rr.operationError(part.Status))
+			rr.failure = rr.queryErr()
+			rr.finish()
+
+			return nil, nil
+		}
+
+		if part.TxMeta != nil {
+			rr.txID = part.TxMeta.Id
+		}
+
+		if len(part.Issues) > 0 {
+			rr.err = errors.Join(ErrIssues, rr.err)
+		}
+
+		if part.ExecStats != nil {
+			// stats on the last part
+			rr.stats = part.ExecStats
+			rr.completed = true
+			rr.finish()
+		}
+
+		if part.ResultSet != nil {
+			return part, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// operationError creates error for unsuccessful part status
+// with issues received so far.
+func (rr *resultReader) operationError(code Ydb.StatusIds_StatusCode) *ydberr.OperationError {
+	opErr := ydberr.NewOperationError(code, rr.issues)
+	opErr.Query = rr.query
+	opErr.Endpoint, opErr.NodeID = rr.origin()
+
+	return opErr
+}
+
+// statsSummary returns summary of received query stats.
+func (rr *resultReader) statsSummary() StatsSummary {
+	summary := NewStatsSummary(rr.stats)
+	summary.Endpoint, summary.NodeID = rr.origin()
+
+	return summary
+}
+
+// origin returns endpoint and node id which executed query, if known.
+func (rr *resultReader) origin() (string, int64) {
+	if o, ok := rr.stream.(interface{ Origin() (string, int64) }); ok {
+		return o.Origin()
+	}
+
+	return "", 0
+}
+
+// queryErr returns query error which renders issue tree with %+v.
+func (rr *resultReader) queryErr() error {
+	if rr.err == nil {
+		return nil
+	}
+
+	return &issuesError{
+		err:    rr.err,
+		issues: rr.issues,
+		query:  rr.query,
+	}
+}
+
+// finish closes result stream,
+// result metadata remains available.
+func (rr *resultReader) finish() {
+	if rr.done {
+		return
+	}
+
+	rr.done = true
+	rr.cancel()
+
+	if rr.onFinish != nil {
+		rr.onFinish()
+	}
+}
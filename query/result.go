@@ -3,8 +3,6 @@ package query
 import (
 	"context"
 	"errors"
-	"fmt"
-	"sync/atomic"
 
 	"github.com/adwski/ydb-go-query/internal/logger"
 
@@ -20,129 +18,113 @@ var (
 	ErrIssues     = errors.New("query result has issues")
 )
 
-type Result struct {
-	logger logger.Logger
-
-	cancel context.CancelFunc
-
-	stream Ydb_Query_V1.QueryService_ExecuteQueryClient
-
-	stats *Ydb_TableStats.QueryStats
-
-	err error
+type (
+	// CollectFunc is called for every received part of result set
+	// with result set index, its columns and rows of the part.
+	CollectFunc func(idx int64, cols []*Ydb.Column, rows []*Ydb.Value) error
+)
 
-	txID string
+type Result struct {
+	*resultReader
 
-	collectRowsFunc func([]*Ydb.Value) error
+	collectRowsFunc CollectFunc
 
-	issues []*Ydb_Issue.IssueMessage
-	cols   []*Ydb.Column
-	rows   []*Ydb.Value
+	sets resultSets
 
-	done atomic.Bool
+	// rowsCollected indicates that some rows were
+	// already passed to collectRowsFunc.
+	rowsCollected bool
 }
 
 func newResult(
 	stream Ydb_Query_V1.QueryService_ExecuteQueryClient,
 	cancel context.CancelFunc,
 	logger logger.Logger,
-	collectRowsFunc func([]*Ydb.Value) error,
+	collectRowsFunc CollectFunc,
 ) *Result {
 	return &Result{
-		logger: logger,
-		stream: stream,
-		cancel: cancel,
+		resultReader: newResultReader(stream, cancel, logger),
 
 		collectRowsFunc: collectRowsFunc,
 	}
 }
 
-// close closes result stream,
-// result data remains available.
-func (r *Result) close() {
-	r.cancel()
-	r.done.Store(true)
-}
-
 func (r *Result) Err() error {
-	return r.err
+	return r.queryErr()
 }
 
 func (r *Result) Issues() []*Ydb_Issue.IssueMessage { return r.issues }
 
+// Cols returns columns of the first result set.
 func (r *Result) Cols() []*Ydb.Column {
-	return r.cols
+	if first := r.first(); first != nil {
+		return first.cols
+	}
+
+	return nil
 }
 
+// Rows returns rows of the first result set.
 func (r *Result) Rows() []*Ydb.Value {
-	return r.rows
+	if first := r.first(); first != nil {
+		return first.rows
+	}
+
+	return nil
+}
+
+// ResultSets returns all result sets of the query ordered by index.
+func (r *Result) ResultSets() []*ResultSet {
+	return r.sets.list()
+}
+
+func (r *Result) first() *ResultSet {
+	if len(r.sets) == 0 {
+		return nil
+	}
+
+	return r.sets[0]
 }
 
 func (r *Result) Stats() *Ydb_TableStats.QueryStats {
 	return r.stats
 }
 
+// StatsSummary returns summary of query stats.
+func (r *Result) StatsSummary() StatsSummary {
+	return r.statsSummary()
+}
+
 func (r *Result) TxID() string {
 	return r.txID
 }
 
 // recv reads all parts from result stream till completion.
-// It assumes that parts are arriving sequentially,
-// i.e. ConcurrentResultSets is false.
+// Parts are distributed among result sets according to their indexes,
+// so parts of different result sets may arrive interleaved.
 func (r *Result) recv() error {
-	if r.done.Load() {
-		return nil
-	}
 	for {
-		part, err := r.stream.Recv()
-		r.logger.Trace("received result part", "part", part, "error", err)
+		part, err := r.next()
 		if err != nil {
-			return errors.Join(ErrStream, err)
+			return err
 		}
-
-		r.issues = append(r.issues, part.Issues...)
-
-		if part.Status != Ydb.StatusIds_SUCCESS {
-			r.err = errors.Join(ErrPartStatus, fmt.Errorf("status: %s", part.Status))
-
-			break
+		if part == nil {
+			return nil
 		}
 
-		if part.TxMeta != nil {
-			r.txID = part.TxMeta.Id
-		}
+		set := r.sets.get(part.ResultSetIndex)
+		set.addPart(part.ResultSet, r.collectRowsFunc == nil)
 
-		if len(part.Issues) > 0 {
-			r.err = errors.Join(ErrIssues, r.err)
+		if r.collectRowsFunc == nil || len(part.ResultSet.Rows) == 0 {
+			continue
 		}
 
-		if part.ResultSet != nil {
-			if r.cols == nil && len(part.ResultSet.Columns) > 0 {
-				r.cols = part.ResultSet.Columns
-			}
-
-			if len(part.ResultSet.Rows) > 0 {
-				if r.collectRowsFunc != nil {
-					err = r.collectRowsFunc(part.ResultSet.Rows)
-					if err != nil {
-						r.err = errors.Join(err, r.err)
-						break
-					}
-				} else {
-					r.rows = append(r.rows, part.ResultSet.Rows...)
-				}
-			}
-		}
+		r.rowsCollected = true
+		if err = r.collectRowsFunc(set.index, set.cols, part.ResultSet.Rows); err != nil {
+			r.err = errors.Join(err, r.err)
+			r.finish()
 
-		if part.ExecStats != nil {
-			// stats on the last part
-			// TODO: find better way to detect last part
-			r.stats = part.ExecStats
-			break
+			return nil
 		}
 	}
-
-	r.close()
-
-	return nil
 }
@@ -0,0 +1,163 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+func newTestResult(parts []*Ydb_Query.ExecuteQueryResponsePart, collect CollectFunc) (*Result, *bool) {
+	var canceled bool
+	return newResult(&fakeStream{parts: parts}, func() { canceled = true },
+		logger.New(noop.NewLogger()), collect), &canceled
+}
+
+// interleavedParts returns parts of two result sets as they
+// may arrive with ConcurrentResultSets enabled.
+func interleavedParts() []*Ydb_Query.ExecuteQueryResponsePart {
+	return []*Ydb_Query.ExecuteQueryResponsePart{
+		{
+			Status:         Ydb.StatusIds_SUCCESS,
+			ResultSetIndex: 1,
+			ResultSet: &Ydb.ResultSet{
+				Columns: []*Ydb.Column{uint64Col("cnt")},
+				Rows:    []*Ydb.Value{uint64Row(100)},
+			},
+		},
+		{
+			Status:         Ydb.StatusIds_SUCCESS,
+			ResultSetIndex: 0,
+			ResultSet: &Ydb.ResultSet{
+				Columns: []*Ydb.Column{uint64Col("id"), uint64Col("val")},
+				Rows:    []*Ydb.Value{uint64Row(1, 10)},
+			},
+		},
+		{
+			Status:         Ydb.StatusIds_SUCCESS,
+			ResultSetIndex: 1,
+			ResultSet: &Ydb.ResultSet{
+				Rows:      []*Ydb.Value{uint64Row(200)},
+				Truncated: true,
+			},
+		},
+		{
+			Status:         Ydb.StatusIds_SUCCESS,
+			ResultSetIndex: 0,
+			ResultSet: &Ydb.ResultSet{
+				Rows: []*Ydb.Value{uint64Row(2, 20)},
+			},
+			ExecStats: &Ydb_TableStats.QueryStats{},
+		},
+	}
+}
+
+func TestResult_Recv(t *testing.T) {
+	res, canceled := newTestResult(testParts(), nil)
+
+	require.NoError(t, res.recv())
+	assert.True(t, *canceled)
+	assert.NoError(t, res.Err())
+	assert.Len(t, res.Rows(), 3)
+	assert.Equal(t, "id", res.Cols()[0].Name)
+	assert.Equal(t, "tx", res.TxID())
+	assert.NotNil(t, res.Stats())
+	assert.Len(t, res.ResultSets(), 1)
+}
+
+func TestResult_RecvEmpty(t *testing.T) {
+	res, _ := newTestResult(nil, nil)
+
+	require.NoError(t, res.recv())
+	assert.Nil(t, res.Cols())
+	assert.Nil(t, res.Rows())
+	assert.Empty(t, res.ResultSets())
+}
+
+func TestResult_RecvResultSets(t *testing.T) {
+	res, _ := newTestResult(interleavedParts(), nil)
+
+	require.NoError(t, res.recv())
+
+	sets := res.ResultSets()
+	require.Len(t, sets, 2)
+
+	assert.Equal(t, int64(0), sets[0].Index())
+	assert.Len(t, sets[0].Cols(), 2)
+	assert.Equal(t, []*Ydb.Value{uint64Row(1, 10), uint64Row(2, 20)}, sets[0].Rows())
+	assert.False(t, sets[0].Truncated())
+
+	assert.Equal(t, int64(1), sets[1].Index())
+	assert.Equal(t, "cnt", sets[1].Cols()[0].Name)
+	assert.Equal(t, []*Ydb.Value{uint64Row(100), uint64Row(200)}, sets[1].Rows())
+	assert.True(t, sets[1].Truncated())
+
+	// first result set
+	assert.Equal(t, sets[0].Cols(), res.Cols())
+	assert.Equal(t, sets[0].Rows(), res.Rows())
+}
+
+func TestResult_RecvCollect(t *testing.T) {
+	var (
+		idxs []int64
+		cols []string
+	)
+	res, _ := newTestResult(interleavedParts(), func(idx int64, c []*Ydb.Column, rows []*Ydb.Value) error {
+		idxs = append(idxs, idx)
+		cols = append(cols, c[0].Name)
+		return nil
+	})
+
+	require.NoError(t, res.recv())
+	assert.Equal(t, []int64{1, 0, 1, 0}, idxs)
+	assert.Equal(t, []string{"cnt", "id", "cnt", "id"}, cols)
+	assert.True(t, res.rowsCollected)
+	assert.Empty(t, res.Rows())
+	assert.Len(t, res.ResultSets(), 2)
+}
+
+func TestResult_RecvCollectError(t *testing.T) {
+	errCollect := errors.New("collect")
+	res, canceled := newTestResult(interleavedParts(), func(int64, []*Ydb.Column, []*Ydb.Value) error {
+		return errCollect
+	})
+
+	require.NoError(t, res.recv())
+	assert.True(t, *canceled)
+	assert.ErrorIs(t, res.Err(), errCollect)
+}
+
+func TestResult_ErrFormat(t *testing.T) {
+	res, _ := newTestResult([]*Ydb_Query.ExecuteQueryResponsePart{{
+		Status: Ydb.StatusIds_GENERIC_ERROR,
+		Issues: []*Ydb_Issue.IssueMessage{{
+			Position: &Ydb_Issue.IssueMessage_Position{Row: 1, Column: 8},
+			Message:  "Unknown name: $id",
+			Severity: 1,
+		}},
+	}}, nil)
+	res.query = "SELECT $id;"
+
+	require.NoError(t, res.recv())
+	require.ErrorIs(t, res.Err(), ErrPartStatus)
+
+	var opErr *ydberr.OperationError
+	require.ErrorAs(t, res.Err(), &opErr)
+	assert.Equal(t, "SELECT $id;", opErr.Query)
+
+	assert.Equal(t, res.Err().Error(), fmt.Sprintf("%v", res.Err()))
+	assert.Equal(t, res.Err().Error()+`
+ERROR 1:8: Unknown name: $id
+  1 | SELECT $id;
+    |        ^`, fmt.Sprintf("%+v", res.Err()))
+}
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// ResultSet holds rows of a single result set of the query.
+type ResultSet struct {
+	cols []*Ydb.Column
+	rows []*Ydb.Value
+
+	columns columnCache
+
+	index int64
+
+	truncated bool
+}
+
+// Index returns result set index in query.
+func (rs *ResultSet) Index() int64 {
+	return rs.index
+}
+
+func (rs *ResultSet) Cols() []*Ydb.Column {
+	return rs.cols
+}
+
+func (rs *ResultSet) Rows() []*Ydb.Value {
+	return rs.rows
+}
+
+// Truncated indicates that result set was truncated by YDB
+// because of rows or size limits.
+func (rs *ResultSet) Truncated() bool {
+	return rs.truncated
+}
+
+// addPart adds result set part metadata, rows are added only if keepRows is true.
+func (rs *ResultSet) addPart(part *Ydb.ResultSet, keepRows bool) {
+	if rs.cols == nil && len(part.Columns) > 0 {
+		rs.cols = part.Columns
+	}
+	if part.Truncated {
+		rs.truncated = true
+	}
+	if keepRows && len(part.Rows) > 0 {
+		rs.rows = append(rs.rows, part.Rows...)
+		rs.columns.reset()
+	}
+}
+
+// resultSets holds result sets ordered by index.
+// Parts of different result sets may arrive interleaved.
+type resultSets []*ResultSet
+
+func (sets *resultSets) get(idx int64) *ResultSet {
+	for int64(len(*sets)) <= idx {
+		*sets = append(*sets, nil)
+	}
+	if (*sets)[idx] == nil {
+		(*sets)[idx] = &ResultSet{index: idx}
+	}
+
+	return (*sets)[idx]
+}
+
+// list returns received result sets in order of their indexes.
+func (sets resultSets) list() []*ResultSet {
+	list := make([]*ResultSet, 0, len(sets))
+	for _, set := range sets {
+		if set != nil {
+			list = append(list, set)
+		}
+	}
+
+	return list
+}
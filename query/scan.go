@@ -0,0 +1,343 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/adwski/ydb-go-query/internal/naming"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const (
+	tagName = "ydb"
+)
+
+var (
+	ErrScanColumns  = errors.New("destinations count does not match columns count")
+	ErrScanNoColumn = errors.New("column not found")
+)
+
+type (
+	// Row provides access to values of result row.
+	Row struct {
+		value *Ydb.Value
+		cols  []*Ydb.Column
+	}
+
+	// NamedDest is a scan destination bound to column name.
+	NamedDest struct {
+		dst  any
+		name string
+	}
+
+	// structFields maps column index to struct field index path.
+	// Nil path means column has no corresponding field.
+	structFields [][]int
+)
+
+// NewRow creates Row from bare YDB row and result set columns,
+// for example inside Collect func or when iterating over result stream.
+func NewRow(cols []*Ydb.Column, value *Ydb.Value) Row {
+	return Row{
+		cols:  cols,
+		value: value,
+	}
+}
+
+// Named binds scan destination to column name.
+func Named(name string, dst any) NamedDest {
+	return NamedDest{
+		name: name,
+		dst:  dst,
+	}
+}
+
+func (r Row) Cols() []*Ydb.Column {
+	return r.cols
+}
+
+func (r Row) Values() []*Ydb.Value {
+	return r.value.GetItems()
+}
+
+// Scan decodes row values into destinations in order of columns.
+// Destinations must be pointers. Supported destinations are
+// Go counterparts of YDB primitive types, slices for List types, time.Time for
+// date and time types including wide and Tz variants, time.Duration for Interval and Interval64,
+// types.DecimalValue, big.Rat and string for Decimal, [16]byte based types and string for Uuid,
+// *any and sql.Scanner implementations.
+//
+// NULL values of Optional types can be scanned into pointers (nil is set),
+// sql.Scanner implementations (like sql.NullString) and *any.
+func (r Row) Scan(dst ...any) error {
+	if len(dst) != len(r.cols) {
+		return fmt.Errorf("%w: %d destinations, %d columns", ErrScanColumns, len(dst), len(r.cols))
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	for idx, d := range dst {
+		if err := scanColumn(r.cols[idx], r.value.Items[idx], d); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScanNamed decodes row values into destinations bound to column names.
+func (r Row) ScanNamed(dst ...NamedDest) error {
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	for _, nd := range dst {
+		idx := r.colIndex(nd.name)
+		if idx < 0 {
+			return fmt.Errorf("%w: %q", ErrScanNoColumn, nd.name)
+		}
+		if err := scanColumn(r.cols[idx], r.value.Items[idx], nd.dst); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScanStruct decodes row values into struct fields.
+// Columns are mapped to fields using `ydb:"column"` tags, fields without tags
+// are matched to columns by name case-insensitively or by snake_case form of the name.
+// Fields tagged with `ydb:"-"` are skipped. Embedded structs are flattened,
+// except unexported embedded pointers which cannot be allocated
+// and recursively embedded structs which are flattened only once.
+// Columns without corresponding fields are ignored.
+func (r Row) ScanStruct(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected pointer to struct, got %T", ErrScanDest, dst)
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+
+	return r.scanStruct(v.Elem(), mapStructFields(v.Elem().Type(), r.cols))
+}
+
+func (r Row) scanStruct(v reflect.Value, fields structFields) error {
+	for idx, path := range fields {
+		if path == nil {
+			continue
+		}
+		if err := scanValue(fieldByIndex(v, path), r.cols[idx].Type, r.value.Items[idx]); err != nil {
+			return fmt.Errorf("column %q: %w", r.cols[idx].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex but allocates nil embedded pointers.
+func fieldByIndex(v reflect.Value, path []int) reflect.Value {
+	for i, idx := range path {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+
+	return v
+}
+
+func (r Row) validate() error {
+	if len(r.value.GetItems()) != len(r.cols) {
+		return fmt.Errorf("%w: row has %d values, %d columns",
+			ErrScanColumns, len(r.value.GetItems()), len(r.cols))
+	}
+
+	return nil
+}
+
+func (r Row) colIndex(name string) int {
+	for idx, col := range r.cols {
+		if col.Name == name {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func scanColumn(col *Ydb.Column, val *Ydb.Value, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("column %q: %w: expected non-nil pointer, got %T", col.Name, ErrScanDest, dst)
+	}
+	if err := scanValue(v.Elem(), col.Type, val); err != nil {
+		return fmt.Errorf("column %q: %w", col.Name, err)
+	}
+
+	return nil
+}
+
+// Row returns row of result set by its index.
+func (rs *ResultSet) Row(idx int) Row {
+	return NewRow(rs.cols, rs.rows[idx])
+}
+
+// ScanStructs decodes all rows of result set into slice of structs.
+// Dst must be pointer to slice of structs or pointers to structs,
+// decoded rows are appended to it. See Row.ScanStruct for mapping rules.
+func (rs *ResultSet) ScanStructs(dst any) error {
+	return scanStructs(rs.cols, rs.rows, dst)
+}
+
+// ScanStructs decodes all rows of the first result set into slice of structs.
+// See ResultSet.ScanStructs.
+func (r *Result) ScanStructs(dst any) error {
+	first := r.first()
+	if first == nil {
+		return scanStructs(nil, nil, dst)
+	}
+
+	return first.ScanStructs(dst)
+}
+
+func scanStructs(cols []*Ydb.Column, rows []*Ydb.Value, dst any) error {
+	sliceV := reflect.ValueOf(dst)
+	if sliceV.Kind() != reflect.Pointer || sliceV.IsNil() || sliceV.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: expected pointer to slice, got %T", ErrScanDest, dst)
+	}
+	sliceV = sliceV.Elem()
+
+	elemType := sliceV.Type().Elem()
+	structType, isPtr := elemType, false
+	if elemType.Kind() == reflect.Pointer {
+		structType, isPtr = elemType.Elem(), true
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected slice of structs, got %T", ErrScanDest, dst)
+	}
+
+	fields := mapStructFields(structType, cols)
+	if sliceV.Cap()-sliceV.Len() < len(rows) {
+		grown := reflect.MakeSlice(sliceV.Type(), sliceV.Len(), sliceV.Len()+len(rows))
+		reflect.Copy(grown, sliceV)
+		sliceV.Set(grown)
+	}
+
+	for rIdx, value := range rows {
+		row := NewRow(cols, value)
+		if err := row.validate(); err != nil {
+			return fmt.Errorf("row %d: %w", rIdx, err)
+		}
+
+		elem := reflect.New(structType)
+		if err := row.scanStruct(elem.Elem(), fields); err != nil {
+			return fmt.Errorf("row %d: %w", rIdx, err)
+		}
+		if !isPtr {
+			elem = elem.Elem()
+		}
+		sliceV.Set(reflect.Append(sliceV, elem))
+	}
+
+	return nil
+}
+
+// fieldsCache holds column names of struct types.
+var fieldsCache sync.Map // map[reflect.Type][]structField
+
+type structField struct {
+	name  string // tag name or field name
+	snake string // snake_case form of field name, empty if tagged
+	index []int
+}
+
+func mapStructFields(t reflect.Type, cols []*Ydb.Column) structFields {
+	fields := typeFields(t)
+	mapping := make(structFields, len(cols))
+
+	for cIdx, col := range cols {
+		for _, f := range fields {
+			if f.snake == "" {
+				if f.name == col.Name {
+					mapping[cIdx] = f.index
+					break
+				}
+				continue
+			}
+			if strings.EqualFold(f.name, col.Name) || f.snake == col.Name {
+				mapping[cIdx] = f.index
+				break
+			}
+		}
+	}
+
+	return mapping
+}
+
+func typeFields(t reflect.Type) []structField {
+	if cached, ok := fieldsCache.Load(t); ok {
+		return cached.([]structField) //nolint:errcheck,forcetypeassert // always []structField
+	}
+
+	fields := collectFields(t, nil, make(map[reflect.Type]bool))
+	fieldsCache.Store(t, fields)
+
+	return fields
+}
+
+// collectFields returns fields of struct type t including fields of embedded structs.
+// Path holds struct types which are being collected, embedded struct
+// which is already on the path is skipped, so recursive types are not traversed endlessly.
+func collectFields(t reflect.Type, parent []int, path map[reflect.Type]bool) []structField {
+	path[t] = true
+	defer delete(path, t)
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get(tagName), ",")
+		if name == "-" {
+			continue
+		}
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				if !sf.IsExported() {
+					// nil pointer cannot be allocated through unexported field
+					continue
+				}
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if !path[ft] {
+					fields = append(fields, collectFields(ft, index, path)...)
+				}
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name != "" {
+			fields = append(fields, structField{name: name, index: index})
+		} else {
+			fields = append(fields, structField{name: sf.Name, snake: naming.Snake(sf.Name), index: index})
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,300 @@
+package query
+
+import (
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func primitiveType(id Ydb.Type_PrimitiveTypeId) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}}
+}
+
+func optionalType(item *Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_OptionalType{OptionalType: &Ydb.OptionalType{Item: item}}}
+}
+
+func nullValue() *Ydb.Value {
+	return &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}
+}
+
+func testRowCols() []*Ydb.Column {
+	return []*Ydb.Column{
+		uint64Col("id"),
+		{Name: "user_name", Type: primitiveType(Ydb.Type_UTF8)},
+		{Name: "email", Type: optionalType(primitiveType(Ydb.Type_UTF8))},
+		{Name: "created_at", Type: primitiveType(Ydb.Type_TIMESTAMP)},
+		{Name: "score", Type: primitiveType(Ydb.Type_INT32)},
+	}
+}
+
+func testRow(id uint64, name string, email *string, score int32) *Ydb.Value {
+	emailV := nullValue()
+	if email != nil {
+		emailV = &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: *email}}
+	}
+
+	return &Ydb.Value{Items: []*Ydb.Value{
+		{Value: &Ydb.Value_Uint64Value{Uint64Value: id}},
+		{Value: &Ydb.Value_TextValue{TextValue: name}},
+		emailV,
+		{Value: &Ydb.Value_Uint64Value{Uint64Value: 1_700_000_000_000_000}},
+		{Value: &Ydb.Value_Int32Value{Int32Value: score}},
+	}}
+}
+
+type Audit struct {
+	CreatedAt time.Time
+}
+
+// Node and Branch are recursive embedded types.
+type Node struct {
+	*Node
+	ID uint64
+}
+
+type Branch struct {
+	*Leaf
+	Name string `ydb:"user_name"`
+}
+
+type Leaf struct {
+	*Branch
+	Score int8
+}
+
+type testUser struct {
+	Audit
+	Name   string `ydb:"user_name"`
+	Email  *string
+	Secret string `ydb:"-"`
+	ID     uint64
+	Score  int8
+}
+
+func TestRow_Scan(t *testing.T) {
+	email := "a@b.c"
+	row := NewRow(testRowCols(), testRow(1, "alice", &email, 42))
+
+	var (
+		id      uint64
+		name    string
+		emailNS sql.NullString
+		created time.Time
+		score   any
+	)
+	require.NoError(t, row.Scan(&id, &name, &emailNS, &created, &score))
+	assert.Equal(t, uint64(1), id)
+	assert.Equal(t, "alice", name)
+	assert.Equal(t, sql.NullString{String: email, Valid: true}, emailNS)
+	assert.Equal(t, time.UnixMicro(1_700_000_000_000_000).UTC(), created)
+	assert.Equal(t, int64(42), score)
+
+	err := row.Scan(&id)
+	require.ErrorIs(t, err, ErrScanColumns)
+
+	err = row.Scan(&id, &id, &name, &created, &score)
+	require.ErrorIs(t, err, ErrScanType)
+	assert.Contains(t, err.Error(), `column "user_name"`)
+
+	err = row.Scan(id, &name, &emailNS, &created, &score)
+	require.ErrorIs(t, err, ErrScanDest)
+}
+
+func TestRow_ScanNull(t *testing.T) {
+	row := NewRow(testRowCols(), testRow(1, "alice", nil, 42))
+
+	var (
+		email  = new(string)
+		nullNS = sql.NullString{String: "x", Valid: true}
+		str    string
+	)
+	require.NoError(t, row.ScanNamed(Named("email", &email)))
+	assert.Nil(t, email)
+
+	require.NoError(t, row.ScanNamed(Named("email", &nullNS)))
+	assert.False(t, nullNS.Valid)
+
+	require.ErrorIs(t, row.ScanNamed(Named("email", &str)), ErrScanNull)
+	require.ErrorIs(t, row.ScanNamed(Named("unknown", &str)), ErrScanNoColumn)
+}
+
+func TestRow_ScanOverflow(t *testing.T) {
+	row := NewRow(testRowCols(), testRow(1, "alice", nil, 300))
+
+	var score int8
+	require.ErrorIs(t, row.ScanNamed(Named("score", &score)), ErrScanOverflow)
+
+	var user testUser
+	require.ErrorIs(t, row.ScanStruct(&user), ErrScanOverflow)
+}
+
+func TestRow_ScanTemporal(t *testing.T) {
+	row := NewRow([]*Ydb.Column{
+		{Name: "born", Type: primitiveType(types.TypeDate32)},
+		{Name: "local", Type: primitiveType(Ydb.Type_TZ_DATETIME)},
+		{Name: "ttl", Type: primitiveType(types.TypeInterval64)},
+	}, &Ydb.Value{Items: []*Ydb.Value{
+		{Value: &Ydb.Value_Int32Value{Int32Value: -1}},
+		{Value: &Ydb.Value_TextValue{TextValue: "2024-09-20T15:30:15,Europe/Moscow"}},
+		{Value: &Ydb.Value_Int64Value{Int64Value: 1_000_000}},
+	}})
+
+	var (
+		born, local time.Time
+		ttl         time.Duration
+	)
+	require.NoError(t, row.Scan(&born, &local, &ttl))
+	assert.Equal(t, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC), born)
+	assert.Equal(t, "Europe/Moscow", local.Location().String())
+	assert.Equal(t, 15, local.Hour())
+	assert.Equal(t, time.Second, ttl)
+
+	require.ErrorIs(t, row.Scan(&born, &local, &born), ErrScanType)
+}
+
+func TestRow_ScanDecimal(t *testing.T) {
+	amount, err := types.DecimalFromString("-10.25", 22, 2)
+	require.NoError(t, err)
+	row := NewRow([]*Ydb.Column{
+		{Name: "amount", Type: amount.Type},
+		{Name: "price", Type: optionalType(amount.Type)},
+	}, &Ydb.Value{Items: []*Ydb.Value{amount.Value, amount.Value}})
+
+	var (
+		dec   types.DecimalValue
+		price *big.Rat
+		str   string
+		anyV  any
+	)
+	require.NoError(t, row.Scan(&dec, &price))
+	assert.Equal(t, "-10.25", dec.String())
+	assert.Equal(t, big.NewRat(-41, 4), price)
+
+	require.NoError(t, row.Scan(&str, &anyV))
+	assert.Equal(t, "-10.25", str)
+	assert.Equal(t, "-10.25", anyV)
+
+	var f float64
+	require.ErrorIs(t, row.Scan(&f, &str), ErrScanType)
+}
+
+func TestRow_ScanUUID(t *testing.T) {
+	u, err := types.ParseUUID("00112233-4455-6677-8899-aabbccddeeff")
+	require.NoError(t, err)
+	tv := types.UUID(u)
+	row := NewRow([]*Ydb.Column{{Name: "id", Type: tv.Type}, {Name: "ref", Type: tv.Type}},
+		&Ydb.Value{Items: []*Ydb.Value{tv.Value, tv.Value}})
+
+	var (
+		id  [16]byte
+		ref string
+	)
+	require.NoError(t, row.Scan(&id, &ref))
+	assert.Equal(t, [16]byte(u), id)
+	assert.Equal(t, "00112233-4455-6677-8899-aabbccddeeff", ref)
+
+	var short [8]byte
+	require.ErrorIs(t, row.Scan(&short, &ref), ErrScanType)
+}
+
+func TestRow_ScanAny(t *testing.T) {
+	tags, err := types.List(types.Int32(1), types.Int32(2))
+	require.NoError(t, err)
+	attrs := types.Struct(types.Field("tags", tags), types.Field("score", types.Float(1.5)))
+	row := NewRow([]*Ydb.Column{{Name: "attrs", Type: attrs.Type}}, &Ydb.Value{Items: []*Ydb.Value{attrs.Value}})
+
+	var v any
+	require.NoError(t, row.Scan(&v))
+	assert.Equal(t, map[string]any{"tags": []any{int64(1), int64(2)}, "score": float64(1.5)}, v)
+
+	attrs.Value.Items[0].Items[1] = types.UTF8("x").Value
+	err = row.Scan(&v)
+	require.ErrorIs(t, err, ErrScanType)
+	assert.Contains(t, err.Error(), `column "attrs": type mismatch: .tags[1]: cannot decode value: expected Int32`)
+}
+
+func TestRow_ScanStruct(t *testing.T) {
+	email := "a@b.c"
+	row := NewRow(testRowCols(), testRow(1, "alice", &email, 42))
+
+	user := testUser{Secret: "keep"}
+	require.NoError(t, row.ScanStruct(&user))
+	assert.Equal(t, testUser{
+		Audit:  Audit{CreatedAt: time.UnixMicro(1_700_000_000_000_000).UTC()},
+		Name:   "alice",
+		Email:  &email,
+		Secret: "keep",
+		ID:     1,
+		Score:  42,
+	}, user)
+
+	require.ErrorIs(t, row.ScanStruct(user), ErrScanDest)
+
+	// nil embedded pointers are allocated, unexported ones are skipped
+	type audit struct {
+		CreatedAt time.Time
+	}
+	var embedded struct {
+		*Audit
+		*audit
+		Name string `ydb:"user_name"`
+	}
+	require.NoError(t, row.ScanStruct(&embedded))
+	require.NotNil(t, embedded.Audit)
+	assert.Equal(t, time.UnixMicro(1_700_000_000_000_000).UTC(), embedded.Audit.CreatedAt)
+	assert.Nil(t, embedded.audit)
+	assert.Equal(t, "alice", embedded.Name)
+
+	var unexported struct {
+		*audit
+		Name string `ydb:"user_name"`
+	}
+	require.NoError(t, row.ScanStruct(&unexported))
+	assert.Nil(t, unexported.audit)
+	assert.Equal(t, "alice", unexported.Name)
+
+	// recursive embedded types are not traversed again
+	var node Node
+	require.NoError(t, row.ScanStruct(&node))
+	assert.Equal(t, Node{ID: 1}, node)
+
+	var branch Branch
+	require.NoError(t, row.ScanStruct(&branch))
+	assert.Equal(t, "alice", branch.Name)
+	require.NotNil(t, branch.Leaf)
+	assert.Equal(t, int8(42), branch.Leaf.Score)
+	assert.Nil(t, branch.Leaf.Branch)
+}
+
+func TestResultSet_ScanStructs(t *testing.T) {
+	email := "a@b.c"
+	rs := &ResultSet{
+		cols: testRowCols(),
+		rows: []*Ydb.Value{testRow(1, "alice", &email, 1), testRow(2, "bob", nil, 2)},
+	}
+
+	var users []testUser
+	require.NoError(t, rs.ScanStructs(&users))
+	require.Len(t, users, 2)
+	assert.Equal(t, "alice", users[0].Name)
+	assert.Equal(t, &email, users[0].Email)
+	assert.Equal(t, "bob", users[1].Name)
+	assert.Nil(t, users[1].Email)
+
+	var ptrs []*testUser
+	require.NoError(t, rs.ScanStructs(&ptrs))
+	require.Len(t, ptrs, 2)
+	assert.Equal(t, uint64(2), ptrs[1].ID)
+
+	var bad []int
+	require.ErrorIs(t, rs.ScanStructs(&bad), ErrScanDest)
+	require.ErrorIs(t, rs.ScanStructs(users), ErrScanDest)
+}
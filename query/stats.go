@@ -0,0 +1,212 @@
+package query
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+const (
+	// StatsModeNone disables query stats.
+	StatsModeNone = Ydb_Query.StatsMode_STATS_MODE_NONE
+	// StatsModeBasic provides total duration, cpu time and table access stats. It is used by default.
+	StatsModeBasic = Ydb_Query.StatsMode_STATS_MODE_BASIC
+	// StatsModeFull additionally provides query plan.
+	StatsModeFull = Ydb_Query.StatsMode_STATS_MODE_FULL
+	// StatsModeProfile additionally provides detailed execution profile.
+	StatsModeProfile = Ydb_Query.StatsMode_STATS_MODE_PROFILE
+)
+
+type (
+	// OperationStats holds amount of rows and bytes affected by table operation.
+	OperationStats struct {
+		Rows  uint64
+		Bytes uint64
+	}
+
+	// TableStats holds table access stats.
+	TableStats struct {
+		Reads   OperationStats
+		Updates OperationStats
+		Deletes OperationStats
+	}
+
+	// StatsSummary is a summary of query stats aggregated across query phases.
+	// Summaries of several queries can be merged.
+	StatsSummary struct {
+		// Tables holds access stats per table path.
+		Tables map[string]TableStats
+
+		// Endpoint and NodeID identify YDB node which executed query.
+		// They are empty for merged summaries of different nodes.
+		Endpoint string
+		NodeID   int64
+
+		Duration time.Duration
+		CPUTime  time.Duration
+
+		// Queries is the amount of queries summary is built from.
+		Queries int
+	}
+
+	// StatsAggregator accumulates stats summaries of many queries,
+	// in total and per endpoint. It is safe for concurrent use.
+	StatsAggregator struct {
+		endpoints map[string]*StatsSummary
+		total     StatsSummary
+		mx        sync.Mutex
+	}
+)
+
+func (s OperationStats) add(other OperationStats) OperationStats {
+	return OperationStats{
+		Rows:  s.Rows + other.Rows,
+		Bytes: s.Bytes + other.Bytes,
+	}
+}
+
+func (s TableStats) add(other TableStats) TableStats {
+	return TableStats{
+		Reads:   s.Reads.add(other.Reads),
+		Updates: s.Updates.add(other.Updates),
+		Deletes: s.Deletes.add(other.Deletes),
+	}
+}
+
+// NewStatsSummary builds summary of query stats.
+// Nil stats produce summary of single query with zero values.
+func NewStatsSummary(stats *Ydb_TableStats.QueryStats) StatsSummary {
+	summary := StatsSummary{
+		Tables:   make(map[string]TableStats),
+		Duration: time.Duration(stats.GetTotalDurationUs()) * time.Microsecond,
+		CPUTime:  time.Duration(stats.GetTotalCpuTimeUs()) * time.Microsecond,
+		Queries:  1,
+	}
+
+	var phasesDuration, phasesCPU uint64
+	for _, phase := range stats.GetQueryPhases() {
+		phasesDuration += phase.GetDurationUs()
+		phasesCPU += phase.GetCpuTimeUs()
+
+		for _, access := range phase.GetTableAccess() {
+			summary.Tables[access.GetName()] = summary.Tables[access.GetName()].add(TableStats{
+				Reads:   operationStats(access.GetReads()),
+				Updates: operationStats(access.GetUpdates()),
+				Deletes: operationStats(access.GetDeletes()),
+			})
+		}
+	}
+
+	// totals may be not provided by older YDB versions
+	if summary.Duration == 0 {
+		summary.Duration = time.Duration(phasesDuration) * time.Microsecond
+	}
+	if summary.CPUTime == 0 {
+		summary.CPUTime = time.Duration(phasesCPU+stats.GetProcessCpuTimeUs()+
+			stats.GetCompilation().GetCpuTimeUs()) * time.Microsecond
+	}
+
+	return summary
+}
+
+func operationStats(s *Ydb_TableStats.OperationStats) OperationStats {
+	return OperationStats{
+		Rows:  s.GetRows(),
+		Bytes: s.GetBytes(),
+	}
+}
+
+// Total returns access stats summed across all tables.
+func (s *StatsSummary) Total() TableStats {
+	var total TableStats
+	for _, ts := range s.Tables {
+		total = total.add(ts)
+	}
+
+	return total
+}
+
+// Merge adds other summary to s.
+func (s *StatsSummary) Merge(other StatsSummary) {
+	if s.Queries == 0 {
+		s.Endpoint, s.NodeID = other.Endpoint, other.NodeID
+	} else if s.Endpoint != other.Endpoint || s.NodeID != other.NodeID {
+		s.Endpoint, s.NodeID = "", 0
+	}
+
+	if s.Tables == nil {
+		s.Tables = make(map[string]TableStats, len(other.Tables))
+	}
+	for name, ts := range other.Tables {
+		s.Tables[name] = s.Tables[name].add(ts)
+	}
+
+	s.Duration += other.Duration
+	s.CPUTime += other.CPUTime
+	s.Queries += other.Queries
+}
+
+func (s *StatsSummary) clone() StatsSummary {
+	c := *s
+	c.Tables = maps.Clone(s.Tables)
+
+	return c
+}
+
+func NewStatsAggregator() *StatsAggregator {
+	return &StatsAggregator{
+		endpoints: make(map[string]*StatsSummary),
+	}
+}
+
+// Add accumulates query stats summary.
+func (a *StatsAggregator) Add(summary StatsSummary) {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	a.total.Merge(summary)
+
+	if a.endpoints == nil {
+		a.endpoints = make(map[string]*StatsSummary)
+	}
+	ep, ok := a.endpoints[summary.Endpoint]
+	if !ok {
+		ep = &StatsSummary{}
+		a.endpoints[summary.Endpoint] = ep
+	}
+	ep.Merge(summary)
+}
+
+// Total returns summary of all accumulated queries.
+func (a *StatsAggregator) Total() StatsSummary {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	return a.total.clone()
+}
+
+// ByEndpoint returns summaries of accumulated queries per endpoint.
+// Queries with unknown endpoint are accumulated under empty key.
+func (a *StatsAggregator) ByEndpoint() map[string]StatsSummary {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	res := make(map[string]StatsSummary, len(a.endpoints))
+	for ep, summary := range a.endpoints {
+		res[ep] = summary.clone()
+	}
+
+	return res
+}
+
+// Reset drops accumulated stats.
+func (a *StatsAggregator) Reset() {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	a.total = StatsSummary{}
+	a.endpoints = make(map[string]*StatsSummary)
+}
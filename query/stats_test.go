@@ -0,0 +1,111 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+func testQueryStats() *Ydb_TableStats.QueryStats {
+	return &Ydb_TableStats.QueryStats{
+		QueryPhases: []*Ydb_TableStats.QueryPhaseStats{
+			{
+				DurationUs: 100,
+				CpuTimeUs:  50,
+				TableAccess: []*Ydb_TableStats.TableAccessStats{
+					{Name: "/local/users", Reads: &Ydb_TableStats.OperationStats{Rows: 10, Bytes: 100}},
+				},
+			},
+			{
+				DurationUs: 200,
+				CpuTimeUs:  70,
+				TableAccess: []*Ydb_TableStats.TableAccessStats{
+					{Name: "/local/users", Updates: &Ydb_TableStats.OperationStats{Rows: 1, Bytes: 10}},
+					{Name: "/local/orders", Deletes: &Ydb_TableStats.OperationStats{Rows: 2, Bytes: 20}},
+				},
+			},
+		},
+		ProcessCpuTimeUs: 5,
+	}
+}
+
+func TestNewStatsSummary(t *testing.T) {
+	summary := NewStatsSummary(testQueryStats())
+
+	assert.Equal(t, StatsSummary{
+		Tables: map[string]TableStats{
+			"/local/users": {
+				Reads:   OperationStats{Rows: 10, Bytes: 100},
+				Updates: OperationStats{Rows: 1, Bytes: 10},
+			},
+			"/local/orders": {
+				Deletes: OperationStats{Rows: 2, Bytes: 20},
+			},
+		},
+		Duration: 300 * time.Microsecond,
+		CPUTime:  125 * time.Microsecond,
+		Queries:  1,
+	}, summary)
+	assert.Equal(t, TableStats{
+		Reads:   OperationStats{Rows: 10, Bytes: 100},
+		Updates: OperationStats{Rows: 1, Bytes: 10},
+		Deletes: OperationStats{Rows: 2, Bytes: 20},
+	}, summary.Total())
+
+	stats := testQueryStats()
+	stats.TotalDurationUs = 1000
+	stats.TotalCpuTimeUs = 500
+	summary = NewStatsSummary(stats)
+	assert.Equal(t, time.Millisecond, summary.Duration)
+	assert.Equal(t, 500*time.Microsecond, summary.CPUTime)
+
+	summary = NewStatsSummary(nil)
+	assert.Equal(t, 1, summary.Queries)
+	assert.Empty(t, summary.Tables)
+}
+
+func TestStatsAggregator(t *testing.T) {
+	agg := NewStatsAggregator()
+
+	first := NewStatsSummary(testQueryStats())
+	first.Endpoint, first.NodeID = "node1:2136", 1
+	second := NewStatsSummary(testQueryStats())
+	second.Endpoint, second.NodeID = "node2:2136", 2
+
+	agg.Add(first)
+	agg.Add(first)
+	agg.Add(second)
+
+	total := agg.Total()
+	assert.Equal(t, 3, total.Queries)
+	assert.Equal(t, 900*time.Microsecond, total.Duration)
+	assert.Equal(t, uint64(30), total.Tables["/local/users"].Reads.Rows)
+	assert.Empty(t, total.Endpoint)
+
+	byEndpoint := agg.ByEndpoint()
+	assert.Len(t, byEndpoint, 2)
+	assert.Equal(t, 2, byEndpoint["node1:2136"].Queries)
+	assert.Equal(t, int64(1), byEndpoint["node1:2136"].NodeID)
+	assert.Equal(t, uint64(10), byEndpoint["node2:2136"].Tables["/local/users"].Reads.Rows)
+
+	// returned summaries are copies
+	total.Tables["/local/users"] = TableStats{}
+	assert.Equal(t, uint64(30), agg.Total().Tables["/local/users"].Reads.Rows)
+
+	agg.Reset()
+	assert.Zero(t, agg.Total().Queries)
+	assert.Empty(t, agg.ByEndpoint())
+}
+
+func TestStatsAggregator_ZeroValue(t *testing.T) {
+	var agg StatsAggregator
+
+	summary := NewStatsSummary(testQueryStats())
+	summary.Endpoint = "node1:2136"
+	agg.Add(summary)
+
+	assert.Equal(t, 1, agg.Total().Queries)
+	assert.Equal(t, 1, agg.ByEndpoint()["node1:2136"].Queries)
+}
@@ -0,0 +1,197 @@
+package query
+
+import (
+	"context"
+	"iter"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+type (
+	// ResultStream provides access to query result as it arrives.
+	// Result parts are requested from server only when previous part is consumed,
+	// so memory footprint does not depend on result size.
+	//
+	// Stream must be closed with Close() if it was not read till the end.
+	ResultStream struct {
+		*resultReader
+
+		// pending is part received before stream was handed to user
+		pending *Ydb_Query.ExecuteQueryResponsePart
+
+		// sets keeps metadata of received result sets
+		sets resultSets
+
+		cols []*Ydb.Column
+	}
+
+	// ResultPart is a chunk of result set received from stream.
+	ResultPart struct {
+		ResultSet *Ydb.ResultSet
+
+		// Cols holds columns of result set this part belongs to.
+		// Columns are usually sent only with first part of result set.
+		Cols []*Ydb.Column
+
+		// Index is an index of result set in query.
+		Index int64
+
+		columns columnCache
+	}
+)
+
+func newResultStream(
+	stream Ydb_Query_V1.QueryService_ExecuteQueryClient,
+	cancel context.CancelFunc,
+	logger logger.Logger,
+) *ResultStream {
+	return &ResultStream{
+		resultReader: newResultReader(stream, cancel, logger),
+	}
+}
+
+// prefetch receives first part of result, so query errors
+// which occur before any data is received can be retried.
+func (rs *ResultStream) prefetch() error {
+	part, err := rs.next()
+	if err != nil {
+		return err
+	}
+	rs.pending = part
+	rs.track(part)
+
+	return nil
+}
+
+func (rs *ResultStream) nextPart() (*Ydb_Query.ExecuteQueryResponsePart, error) {
+	if rs.pending != nil {
+		part := rs.pending
+		rs.pending = nil
+
+		return part, nil
+	}
+
+	part, err := rs.next()
+	if err != nil || part == nil {
+		return nil, err
+	}
+	rs.track(part)
+
+	return part, nil
+}
+
+// track updates result set metadata with received part.
+func (rs *ResultStream) track(part *Ydb_Query.ExecuteQueryResponsePart) {
+	if part == nil {
+		return
+	}
+
+	set := rs.sets.get(part.ResultSetIndex)
+	set.addPart(part.ResultSet, false)
+	rs.cols = set.cols
+}
+
+// Parts returns iterator over result parts. Iteration stops after first error.
+// It is possible to break iteration and continue it later with another iterator.
+func (rs *ResultStream) Parts() iter.Seq2[*ResultPart, error] {
+	return func(yield func(*ResultPart, error) bool) {
+		for {
+			part, err := rs.nextPart()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if part == nil {
+				return
+			}
+
+			if !yield(&ResultPart{
+				ResultSet: part.ResultSet,
+				Cols:      rs.sets[part.ResultSetIndex].cols,
+				Index:     part.ResultSetIndex,
+			}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Rows returns iterator over rows of all result sets. Iteration stops after first error.
+// It is possible to break iteration and continue it later with another iterator.
+func (rs *ResultStream) Rows() iter.Seq2[*Ydb.Value, error] {
+	return func(yield func(*Ydb.Value, error) bool) {
+		for part, err := range rs.Parts() {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, row := range part.ResultSet.Rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close finishes stream, it is safe to call Close more than once.
+func (rs *ResultStream) Close() {
+	rs.finish()
+}
+
+// Cols returns columns of result set which most recently received part belongs to.
+func (rs *ResultStream) Cols() []*Ydb.Column {
+	return rs.cols
+}
+
+// Err returns query error. It is available only after stream is finished.
+func (rs *ResultStream) Err() error {
+	if !rs.done {
+		return nil
+	}
+
+	return rs.queryErr()
+}
+
+// Issues returns query issues. They are available only after stream is finished.
+func (rs *ResultStream) Issues() []*Ydb_Issue.IssueMessage {
+	if !rs.done {
+		return nil
+	}
+
+	return rs.issues
+}
+
+// Stats returns query stats. They are available only after stream is finished.
+func (rs *ResultStream) Stats() *Ydb_TableStats.QueryStats {
+	if !rs.done {
+		return nil
+	}
+
+	return rs.stats
+}
+
+// StatsSummary returns summary of query stats. It is available only after stream is finished.
+func (rs *ResultStream) StatsSummary() StatsSummary {
+	if !rs.done {
+		return StatsSummary{}
+	}
+
+	return rs.statsSummary()
+}
+
+// TxID returns transaction id. It is available only after stream is finished.
+func (rs *ResultStream) TxID() string {
+	if !rs.done {
+		return ""
+	}
+
+	return rs.txID
+}
@@ -0,0 +1,168 @@
+package query
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"google.golang.org/grpc"
+)
+
+type fakeStream struct {
+	grpc.ClientStream
+
+	parts []*Ydb_Query.ExecuteQueryResponsePart
+	err   error
+	recvd int
+}
+
+func (fs *fakeStream) Recv() (*Ydb_Query.ExecuteQueryResponsePart, error) {
+	if len(fs.parts) == 0 {
+		if fs.err != nil {
+			return nil, fs.err
+		}
+		return nil, io.EOF
+	}
+	part := fs.parts[0]
+	fs.parts = fs.parts[1:]
+	fs.recvd++
+
+	return part, nil
+}
+
+func uint64Row(vals ...uint64) *Ydb.Value {
+	row := &Ydb.Value{}
+	for _, v := range vals {
+		row.Items = append(row.Items, &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: v}})
+	}
+	return row
+}
+
+func uint64Col(name string) *Ydb.Column {
+	return &Ydb.Column{
+		Name: name,
+		Type: &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UINT64}},
+	}
+}
+
+func testParts() []*Ydb_Query.ExecuteQueryResponsePart {
+	return []*Ydb_Query.ExecuteQueryResponsePart{
+		{
+			Status: Ydb.StatusIds_SUCCESS,
+			TxMeta: &Ydb_Query.TransactionMeta{Id: "tx"},
+			ResultSet: &Ydb.ResultSet{
+				Columns: []*Ydb.Column{uint64Col("id")},
+				Rows:    []*Ydb.Value{uint64Row(1), uint64Row(2)},
+			},
+		},
+		{
+			Status: Ydb.StatusIds_SUCCESS,
+			ResultSet: &Ydb.ResultSet{
+				Rows: []*Ydb.Value{uint64Row(3)},
+			},
+		},
+		{
+			Status:    Ydb.StatusIds_SUCCESS,
+			ExecStats: &Ydb_TableStats.QueryStats{TotalDurationUs: 1},
+		},
+	}
+}
+
+func newTestStream(fs *fakeStream) (*ResultStream, *bool) {
+	var canceled bool
+	return newResultStream(fs, func() { canceled = true }, logger.New(noop.NewLogger())), &canceled
+}
+
+func TestResultStream_Rows(t *testing.T) {
+	fs := &fakeStream{parts: testParts()}
+	rs, canceled := newTestStream(fs)
+	require.NoError(t, rs.prefetch())
+
+	assert.Equal(t, "id", rs.Cols()[0].Name)
+	assert.Equal(t, 1, fs.recvd)
+
+	var ids []uint64
+	for row, err := range rs.Rows() {
+		require.NoError(t, err)
+		ids = append(ids, row.Items[0].GetUint64Value())
+		if len(ids) == 2 {
+			// parts are received on demand
+			assert.Equal(t, 1, fs.recvd)
+			assert.Nil(t, rs.Stats())
+			assert.Empty(t, rs.TxID())
+		}
+	}
+
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+	assert.True(t, *canceled)
+	assert.NoError(t, rs.Err())
+	assert.Equal(t, "tx", rs.TxID())
+	assert.Equal(t, uint64(1), rs.Stats().TotalDurationUs)
+}
+
+func TestResultStream_Break(t *testing.T) {
+	rs, canceled := newTestStream(&fakeStream{parts: testParts()})
+
+	for _, err := range rs.Parts() {
+		require.NoError(t, err)
+		break
+	}
+	assert.False(t, *canceled)
+
+	var parts int
+	for _, err := range rs.Parts() {
+		require.NoError(t, err)
+		parts++
+	}
+	assert.Equal(t, 1, parts)
+
+	rs.Close()
+	assert.True(t, *canceled)
+}
+
+func TestResultStream_Status(t *testing.T) {
+	rs, canceled := newTestStream(&fakeStream{parts: []*Ydb_Query.ExecuteQueryResponsePart{{
+		Status: Ydb.StatusIds_OVERLOADED,
+		Issues: []*Ydb_Issue.IssueMessage{{Message: "overloaded"}},
+	}}})
+	require.NoError(t, rs.prefetch())
+
+	assert.True(t, *canceled)
+	assert.ErrorIs(t, rs.Err(), ErrPartStatus)
+	var opErr *ydberr.OperationError
+	require.ErrorAs(t, rs.Err(), &opErr)
+	assert.Equal(t, Ydb.StatusIds_OVERLOADED, opErr.Status)
+	assert.Len(t, opErr.Issues, 1)
+	assert.True(t, ydberr.IsRetryable(rs.Err()))
+	assert.Len(t, rs.Issues(), 1)
+
+	for range rs.Rows() {
+		t.Fatal("rows must be empty")
+	}
+}
+
+func TestResultStream_StreamError(t *testing.T) {
+	errIO := errors.New("io error")
+	fs := &fakeStream{parts: testParts()[:1], err: errIO}
+	rs, canceled := newTestStream(fs)
+
+	var errs []error
+	for _, err := range rs.Rows() {
+		errs = append(errs, err)
+	}
+
+	require.Len(t, errs, 3)
+	assert.ErrorIs(t, errs[2], ErrStream)
+	assert.ErrorIs(t, errs[2], errIO)
+	assert.True(t, *canceled)
+}
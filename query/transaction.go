@@ -8,34 +8,79 @@ import (
 	"github.com/adwski/ydb-go-query/internal/logger"
 	"github.com/adwski/ydb-go-query/internal/query/session"
 
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
 )
 
+const (
+	abortTimeout = 3 * time.Second
+)
+
 var (
 	ErrTxFinished = errors.New("transaction already finished")
+	ErrTxStream   = errors.New("transaction has unfinished result stream")
+
+	ErrTxCommitUnknown = errors.New("transaction commit result is unknown")
 )
 
 type (
+	// txSession executes transaction queries, it is implemented by session.Session.
+	txSession interface {
+		Exec(
+			ctx context.Context,
+			query string,
+			params map[string]*Ydb.TypedValue,
+			txControl *Ydb_Query.TransactionControl,
+			opts session.ExecOptions,
+		) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error)
+		CommitTX(ctx context.Context, txID string) error
+		RollbackTX(ctx context.Context, txID string) error
+	}
+
 	Transaction struct {
 		logger logger.Logger
 
-		sess *session.Session
+		sess txSession
 
 		cleanup func()
 
 		settings *Ydb_Query.TransactionSettings
 
+		// stream is a result stream which is not finished yet,
+		// other queries cannot be executed until it is drained or closed.
+		stream *ResultStream
+
+		// err is an error of failed query, transaction cannot be committed after it.
+		err error
+
 		id string
 
 		finish bool // committed or rolled back
+
+		// inlineCommit makes every query commit transaction
+		inlineCommit bool
+
+		autoDeclare bool
+		statsMode   Ydb_Query.StatsMode
+		syntax      Ydb_Query.Syntax
 	}
 )
 
+// Rollback rolls back transaction. Unfinished result stream is closed.
 func (tx *Transaction) Rollback(ctx context.Context) error {
 	if tx.finish {
 		return ErrTxFinished
 	}
+	tx.closeStream()
+
+	if tx.id == "" {
+		// transaction was not started
+		tx.finish = true
+		tx.cleanup()
+
+		return nil
+	}
 
 	if err := tx.sess.RollbackTX(ctx, tx.id); err != nil {
 		return err //nolint:wrapcheck // unnecessary
@@ -47,10 +92,26 @@ func (tx *Transaction) Rollback(ctx context.Context) error {
 	return nil
 }
 
+// Commit commits transaction. It fails with ErrTxStream if result stream is not finished
+// and with error of failed query if any query of transaction has failed.
 func (tx *Transaction) Commit(ctx context.Context) error {
 	if tx.finish {
 		return ErrTxFinished
 	}
+	if tx.stream != nil {
+		return ErrTxStream
+	}
+	if tx.err != nil {
+		return tx.err
+	}
+
+	if tx.id == "" {
+		// transaction was not started, nothing to commit
+		tx.finish = true
+		tx.cleanup()
+
+		return nil
+	}
 
 	if err := tx.sess.CommitTX(ctx, tx.id); err != nil {
 		return err //nolint:wrapcheck // unnecessary
@@ -62,35 +123,194 @@ func (tx *Transaction) Commit(ctx context.Context) error {
 	return nil
 }
 
+// abort rolls back unfinished transaction and releases its session
+// regardless of rollback result. Rollback is performed even if ctx is already canceled.
+func (tx *Transaction) abort(ctx context.Context) {
+	if tx.finish {
+		return
+	}
+	tx.closeStream()
+
+	if tx.id != "" {
+		rbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortTimeout)
+		defer cancel()
+
+		if err := tx.sess.RollbackTX(rbCtx, tx.id); err != nil {
+			tx.logger.Debug("transaction rollback failed", "txID", tx.id, "error", err)
+		}
+	}
+
+	tx.finish = true
+	tx.cleanup()
+}
+
 func (tx *Transaction) Query(queryContent string) *TxQuery {
-	return newTxQuery(
+	q := newTxQuery(
 		queryContent,
 		tx.exec,
+		tx.openStream,
 	)
+	q.autoDeclare = tx.autoDeclare
+	q.statsMode = tx.statsMode
+	q.syntax = tx.syntax
+	q.commit = tx.inlineCommit
+
+	return q
 }
 
-func (tx *Transaction) exec(
-	ctx context.Context,
-	query string,
-	params map[string]*Ydb.TypedValue,
-	collectRowsFunc func([]*Ydb.Value) error,
-	timeout time.Duration,
-	commit bool,
-) (*Result, error) {
+// closeStream closes unfinished result stream.
+func (tx *Transaction) closeStream() {
+	if tx.stream != nil {
+		tx.stream.Close()
+	}
+}
+
+// drainStream reads the rest of unfinished inline commit stream, so commit result becomes known.
+func (tx *Transaction) drainStream() {
+	if tx.stream == nil || !tx.finish {
+		return
+	}
+	for _, err := range tx.stream.Parts() {
+		if err != nil {
+			return
+		}
+	}
+}
+
+// fail records error of failed query, only the first error is kept.
+func (tx *Transaction) fail(err error) {
+	if tx.err == nil {
+		tx.err = err
+	}
+}
+
+// check records error of query which failed or, in case of commit query,
+// was not read till the end, so transaction is not reported as committed.
+func (tx *Transaction) check(rr *resultReader, commit bool) {
+	switch {
+	case rr.failure != nil:
+		tx.fail(rr.failure)
+	case commit && !rr.completed:
+		tx.fail(ErrTxCommitUnknown)
+	}
+}
+
+func (tx *Transaction) exec(ctx context.Context, q *TxQuery) (*Result, error) {
 	if tx.finish {
 		return nil, ErrTxFinished
 	}
+	if tx.stream != nil {
+		return nil, ErrTxStream
+	}
 
-	if commit {
+	if q.commit {
 		defer func() {
 			tx.finish = true
 			tx.cleanup()
 		}()
 	}
 
+	content, params, err := q.render()
+	if err != nil {
+		if q.commit {
+			tx.fail(err)
+		}
+		return nil, err
+	}
+
+	stream, cancel, err := tx.open(ctx, q, content, params)
+	if err != nil {
+		tx.fail(err)
+		return nil, err
+	}
+
+	res := newResult(stream, cancel, tx.logger, q.collectRowsFunc)
+	res.query = content
+
+	err = res.recv()
+	tx.check(res.resultReader, q.commit)
+	if err != nil {
+		return nil, errors.Join(ErrResult, err)
+	}
+
+	tx.id = res.TxID()
+	tx.logger.Trace("received tx result", "txID", tx.id)
+
+	return res, nil
+}
+
+func (tx *Transaction) openStream(ctx context.Context, q *TxQuery) (*ResultStream, error) {
+	if tx.finish {
+		return nil, ErrTxFinished
+	}
+	if tx.stream != nil {
+		return nil, ErrTxStream
+	}
+
+	var (
+		stream Ydb_Query_V1.QueryService_ExecuteQueryClient
+		cancel context.CancelFunc
+	)
+	content, params, err := q.render()
+	if err == nil {
+		if stream, cancel, err = tx.open(ctx, q, content, params); err != nil {
+			tx.fail(err)
+		}
+	}
+	if err != nil {
+		if q.commit {
+			tx.fail(err)
+			tx.finish = true
+			tx.cleanup()
+		}
+		return nil, err
+	}
+
+	rs := newResultStream(stream, cancel, tx.logger)
+	rs.query = content
+	if q.commit {
+		// session is released only after stream is finished
+		tx.finish = true
+		rs.onFinish = func() {
+			tx.stream = nil
+			tx.check(rs.resultReader, true)
+			tx.cleanup()
+		}
+	} else {
+		rs.onFinish = func() {
+			tx.stream = nil
+			tx.check(rs.resultReader, false)
+			if rs.txID != "" {
+				tx.id = rs.txID
+				tx.logger.Trace("received tx result", "txID", tx.id)
+			}
+		}
+	}
+	tx.stream = rs
+
+	if err = rs.prefetch(); err != nil {
+		return nil, errors.Join(ErrResult, err)
+	}
+	if rs.txID != "" {
+		// tx id usually arrives with first part
+		tx.id = rs.txID
+	}
+
+	return rs, nil
+}
+
+// open starts query execution within transaction.
+// Query timeout applies to the whole stream lifetime
+// and is released with returned cancel func.
+func (tx *Transaction) open(
+	ctx context.Context,
+	q *TxQuery,
+	content string,
+	params map[string]*Ydb.TypedValue,
+) (Ydb_Query_V1.QueryService_ExecuteQueryClient, context.CancelFunc, error) {
 	txControl := &Ydb_Query.TransactionControl{
 		// send last exec with commit
-		CommitTx: commit,
+		CommitTx: q.commit,
 	}
 	if tx.id == "" {
 		// begin tx
@@ -104,31 +324,23 @@ func (tx *Transaction) exec(
 		}
 	}
 
-	var (
-		qCancel context.CancelFunc
-	)
-	if timeout > 0 {
-		ctx, qCancel = context.WithDeadline(ctx, time.Now().Add(timeout))
+	qCancel := func() {}
+	if q.timeout > 0 {
+		ctx, qCancel = context.WithDeadline(ctx, time.Now().Add(q.timeout))
 	}
 
-	stream, cancel, err := tx.sess.Exec(ctx, query, params, txControl)
-	if qCancel != nil {
-		// if ctx was overwritten, then cancel() inherits from qCancel()
-		cancel = qCancel
-	}
+	stream, cancel, err := tx.sess.Exec(ctx, content, params, txControl, session.ExecOptions{
+		ConcurrentResultSets: q.concurrentResultSets,
+		StatsMode:            q.statsMode,
+		Syntax:               q.syntax,
+	})
 	if err != nil {
-		cancel()
-		return nil, err //nolint:wrapcheck //unnecessary
+		qCancel()
+		return nil, nil, err //nolint:wrapcheck //unnecessary
 	}
 
-	res := newResult(stream, cancel, tx.logger, collectRowsFunc)
-
-	if err = res.recv(); err != nil {
-		return nil, errors.Join(ErrResult, err)
-	}
-
-	tx.id = res.TxID()
-	tx.logger.Trace("received tx result", "txID", tx.id)
-
-	return res, nil
+	return stream, func() {
+		cancel()
+		qCancel()
+	}, nil
 }
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_Stream(t *testing.T) {
+	ctx := context.Background()
+	fs := &fakeSession{parts: testParts()}
+	tx, err := newTestCtx(fs).Tx(ctx)
+	require.NoError(t, err)
+
+	rs, err := tx.Query("select stream").Stream(ctx)
+	require.NoError(t, err)
+
+	// queries cannot be sent while stream is open
+	_, err = tx.Query("select 1").Exec(ctx)
+	require.ErrorIs(t, err, ErrTxStream)
+	_, err = tx.Query("select 2").Stream(ctx)
+	require.ErrorIs(t, err, ErrTxStream)
+	require.ErrorIs(t, tx.Commit(ctx), ErrTxStream)
+	assert.Equal(t, []string{"exec select stream"}, fs.calls)
+
+	var rows int
+	for _, err = range rs.Rows() {
+		require.NoError(t, err)
+		rows++
+	}
+	assert.Equal(t, 3, rows)
+
+	_, err = tx.Query("select 1").Exec(ctx)
+	require.NoError(t, err)
+
+	// closed stream also allows next queries
+	rs, err = tx.Query("select stream").Stream(ctx)
+	require.NoError(t, err)
+	rs.Close()
+	require.NoError(t, tx.Commit(ctx))
+
+	assert.Equal(t, []string{"exec select stream", "exec select 1", "exec select stream", "commit tx"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+}
+
+func TestTransaction_StreamRollback(t *testing.T) {
+	ctx := context.Background()
+	fs := &fakeSession{parts: testParts()}
+	tx, err := newTestCtx(fs).Tx(ctx)
+	require.NoError(t, err)
+
+	rs, err := tx.Query("select stream").Stream(ctx)
+	require.NoError(t, err)
+
+	// rollback closes stream
+	require.NoError(t, tx.Rollback(ctx))
+	assert.Equal(t, []string{"exec select stream", "rollback tx"}, fs.calls)
+	assert.Equal(t, 1, fs.released)
+	assert.NoError(t, rs.Err())
+}
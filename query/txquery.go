@@ -5,33 +5,36 @@ import (
 	"time"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
 )
 
 type (
-	txExecFunc func(
-		context.Context,
-		string,
-		map[string]*Ydb.TypedValue,
-		func([]*Ydb.Value) error,
-		time.Duration,
-		bool,
-	) (*Result, error)
+	txExecFunc   func(context.Context, *TxQuery) (*Result, error)
+	txStreamFunc func(context.Context, *TxQuery) (*ResultStream, error)
 
 	TxQuery struct {
-		collectRowsFunc func([]*Ydb.Value) error
+		collectRowsFunc CollectFunc
 		txExecFunc      txExecFunc
+		txStreamFunc    txStreamFunc
 		params          map[string]*Ydb.TypedValue
 		content         string
 		timeout         time.Duration
 		commit          bool
+		autoDeclare     bool
+		args            goArgs
+		statsMode       Ydb_Query.StatsMode
+		syntax          Ydb_Query.Syntax
+
+		concurrentResultSets bool
 	}
 )
 
-func newTxQuery(content string, eF txExecFunc) *TxQuery {
+func newTxQuery(content string, eF txExecFunc, sF txStreamFunc) *TxQuery {
 	return &TxQuery{
 		content: content,
 
-		txExecFunc: eF,
+		txExecFunc:   eF,
+		txStreamFunc: sF,
 	}
 }
 
@@ -41,7 +44,9 @@ func (q *TxQuery) Commit() *TxQuery {
 	return q
 }
 
-func (q *TxQuery) Collect(collectRowsFunc func([]*Ydb.Value) error) *TxQuery {
+// Collect sets func which is called every time result part is received.
+// Result rows are not accumulated in this case.
+func (q *TxQuery) Collect(collectRowsFunc CollectFunc) *TxQuery {
 	q.collectRowsFunc = collectRowsFunc
 
 	return q
@@ -62,12 +67,102 @@ func (q *TxQuery) Params(params map[string]*Ydb.TypedValue) *TxQuery {
 	return q
 }
 
+// ConcurrentResultSets allows YDB to send parts of different
+// result sets concurrently. Parts are distributed among result sets by their indexes.
+func (q *TxQuery) ConcurrentResultSets() *TxQuery {
+	q.concurrentResultSets = true
+
+	return q
+}
+
 func (q *TxQuery) Timeout(timeout time.Duration) *TxQuery {
 	q.timeout = timeout
 
 	return q
 }
 
+// AutoDeclare enables or disables generation of DECLARE statements
+// for query parameters. By default, it is inherited from query context.
+func (q *TxQuery) AutoDeclare(enable bool) *TxQuery {
+	q.autoDeclare = enable
+
+	return q
+}
+
+// Stats sets query stats collection mode. By default, it is inherited from query context.
+func (q *TxQuery) Stats(mode Ydb_Query.StatsMode) *TxQuery {
+	q.statsMode = mode
+
+	return q
+}
+
+// Syntax sets query syntax. By default, it is inherited from query context.
+// AutoDeclare has no effect for PostgreSQL syntax.
+func (q *TxQuery) Syntax(syntax Ydb_Query.Syntax) *TxQuery {
+	q.syntax = syntax
+
+	return q
+}
+
+// Args sets positional query arguments which are passed as $p1, $p2, ... parameters.
+// Parameter types are inferred from Go values with types.FromGo,
+// or with types.PgFromGo if query has PostgreSQL syntax.
+func (q *TxQuery) Args(args ...any) *TxQuery {
+	q.args.positional = args
+
+	return q
+}
+
+// Arg sets query parameter from Go value, its type is inferred with types.FromGo,
+// or with types.PgFromGo if query has PostgreSQL syntax.
+// Conversion errors are returned on query execution.
+func (q *TxQuery) Arg(name string, val any) *TxQuery {
+	q.args.set(name, val)
+
+	return q
+}
+
+// ArgsFromStruct sets query parameters from exported fields of struct with types.ParamsFromStruct.
+// Field `ydb:"name,type=Datetime"` tags define parameter names and types.
+// It is not supported with PostgreSQL syntax.
+func (q *TxQuery) ArgsFromStruct(v any) *TxQuery {
+	q.args.structs = append(q.args.structs, v)
+
+	return q
+}
+
 func (q *TxQuery) Exec(ctx context.Context) (*Result, error) {
-	return q.txExecFunc(ctx, q.content, q.params, q.collectRowsFunc, q.timeout, q.commit)
+	return q.txExecFunc(ctx, q)
+}
+
+// Stream starts query execution and returns result stream
+// which allows to process query result part by part.
+// Transaction cannot execute other queries until stream is finished:
+// until it is read till the end or closed, Exec, Stream and Commit fail with ErrTxStream.
+func (q *TxQuery) Stream(ctx context.Context) (*ResultStream, error) {
+	return q.txStreamFunc(ctx, q)
+}
+
+// Debug returns standalone YQL script of query, which can be run without parameters.
+// DECLARE statements are replaced with assignments of parameter values
+// rendered as YQL literals, for example $id = Uint64("1");.
+// Queries in PostgreSQL syntax are not supported.
+func (q *TxQuery) Debug() (string, error) {
+	return debug(q.content, q.params, &q.args, q.syntax)
+}
+
+// String returns query script produced by Debug. If it cannot be rendered,
+// query content is returned as is.
+func (q *TxQuery) String() string {
+	script, err := q.Debug()
+	if err != nil {
+		return q.content
+	}
+
+	return script
+}
+
+// render returns query content and parameters to be sent to YDB.
+func (q *TxQuery) render() (string, map[string]*Ydb.TypedValue, error) {
+	return render(q.content, q.params, &q.args, q.syntax, q.autoDeclare)
 }
@@ -0,0 +1,191 @@
+// Package scripts provides execution of long-running YQL scripts.
+// Scripts are executed asynchronously as YDB operations, their results
+// are stored on YDB side and can be fetched page by page.
+package scripts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/retry"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Operation_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	operationKind = "scriptexec"
+)
+
+var (
+	ErrExecute        = errors.New("script execute failed")
+	ErrListOperations = errors.New("list operations failed")
+)
+
+type (
+	// Client executes scripts and manages script operations.
+	Client struct {
+		logger logger.Logger
+
+		qsc Ydb_Query_V1.QueryServiceClient
+		osc Ydb_Operation_V1.OperationServiceClient
+
+		retryAttempts int
+	}
+
+	// Script is a script execution request.
+	Script struct {
+		client     *Client
+		params     map[string]*Ydb.TypedValue
+		content    string
+		resultsTTL time.Duration
+		statsMode  Ydb_Query.StatsMode
+	}
+)
+
+func New(logger logger.Logger, transport grpc.ClientConnInterface, retryAttempts int) *Client {
+	return &Client{
+		logger:        logger,
+		qsc:           Ydb_Query_V1.NewQueryServiceClient(transport),
+		osc:           Ydb_Operation_V1.NewOperationServiceClient(transport),
+		retryAttempts: retryAttempts,
+	}
+}
+
+// Script creates script execution request.
+func (c *Client) Script(content string) *Script {
+	return &Script{
+		client:  c,
+		content: content,
+	}
+}
+
+// Operation returns handle of existing script operation, for example
+// to resume waiting for operation started by another process.
+// Operation state is unknown until it is polled.
+func (c *Client) Operation(id string) *Operation {
+	return &Operation{
+		client: c,
+		id:     id,
+	}
+}
+
+// ListOperations returns page of script operations and token of the next page.
+// Empty token requests the first page, empty next token means there are no more pages.
+func (c *Client) ListOperations(ctx context.Context, pageSize uint64, pageToken string) ([]*Operation, string, error) {
+	var resp *Ydb_Operations.ListOperationsResponse
+	err := c.retry(ctx, true, func(ctx context.Context) (err error) {
+		resp, err = c.osc.ListOperations(ctx, &Ydb_Operations.ListOperationsRequest{
+			Kind:      operationKind,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+		if resp.Status != Ydb.StatusIds_SUCCESS {
+			return ydberr.NewOperationError(resp.Status, resp.Issues)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", errors.Join(ErrListOperations, err)
+	}
+
+	ops := make([]*Operation, 0, len(resp.Operations))
+	for _, opProto := range resp.Operations {
+		op := c.Operation(opProto.GetId())
+		if err = op.update(opProto); err != nil {
+			return nil, "", errors.Join(ErrListOperations, err)
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, resp.NextPageToken, nil
+}
+
+func (c *Client) retry(ctx context.Context, idempotent bool, op func(context.Context) error) error {
+	return retry.Do(ctx, retry.Config{
+		Logger:      c.logger,
+		MaxAttempts: c.retryAttempts,
+		Idempotent:  idempotent,
+	}, op)
+}
+
+func (s *Script) Params(params map[string]*Ydb.TypedValue) *Script {
+	s.params = params
+
+	return s
+}
+
+func (s *Script) Param(name string, val *Ydb.TypedValue) *Script {
+	if s.params == nil {
+		s.params = make(map[string]*Ydb.TypedValue)
+	}
+	s.params[name] = val
+
+	return s
+}
+
+// ResultsTTL sets how long script results are stored after script is finished.
+// YDB default is used if not set.
+func (s *Script) ResultsTTL(ttl time.Duration) *Script {
+	s.resultsTTL = ttl
+
+	return s
+}
+
+// Stats sets script stats collection mode.
+func (s *Script) Stats(mode Ydb_Query.StatsMode) *Script {
+	s.statsMode = mode
+
+	return s
+}
+
+// Execute starts script execution and returns its operation.
+// Script execution is not bound to ctx, it continues after Execute returns.
+func (s *Script) Execute(ctx context.Context) (*Operation, error) {
+	req := &Ydb_Query.ExecuteScriptRequest{
+		ExecMode: Ydb_Query.ExecMode_EXEC_MODE_EXECUTE,
+		ScriptContent: &Ydb_Query.QueryContent{
+			Syntax: Ydb_Query.Syntax_SYNTAX_YQL_V1,
+			Text:   s.content,
+		},
+		Parameters: s.params,
+		StatsMode:  s.statsMode,
+	}
+	if s.resultsTTL > 0 {
+		req.ResultsTtl = durationpb.New(s.resultsTTL)
+	}
+
+	var op *Operation
+	err := s.client.retry(ctx, false, func(ctx context.Context) error {
+		opProto, err := s.client.qsc.ExecuteScript(ctx, req)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		op = s.client.Operation(opProto.GetId())
+		if err = op.update(opProto); err != nil {
+			return err
+		}
+
+		return op.Err()
+	})
+	if err != nil {
+		return nil, errors.Join(ErrExecute, err)
+	}
+
+	s.client.logger.Debug("script started", "operation", op.id)
+
+	return op, nil
+}
@@ -0,0 +1,269 @@
+package scripts
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"time"
+
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+)
+
+const (
+	minPollInterval = 100 * time.Millisecond
+	maxPollInterval = 5 * time.Second
+)
+
+var (
+	ErrPoll     = errors.New("operation poll failed")
+	ErrCancel   = errors.New("operation cancel failed")
+	ErrForget   = errors.New("operation forget failed")
+	ErrFetch    = errors.New("script results fetch failed")
+	ErrMetadata = errors.New("cannot read operation metadata")
+)
+
+type (
+	// Operation is a handle of script execution operation.
+	Operation struct {
+		client *Client
+
+		meta *Ydb_Query.ExecuteScriptMetadata
+
+		id     string
+		issues []*Ydb_Issue.IssueMessage
+		status Ydb.StatusIds_StatusCode
+		ready  bool
+	}
+
+	// Page is a part of script result set.
+	Page struct {
+		ResultSet *Ydb.ResultSet
+
+		// NextToken is used to fetch next page,
+		// it is empty if page is the last one.
+		NextToken string
+
+		Index int64
+	}
+)
+
+// ID returns operation id.
+func (op *Operation) ID() string {
+	return op.id
+}
+
+// Ready reports whether script is finished.
+func (op *Operation) Ready() bool {
+	return op.ready
+}
+
+// Issues returns operation issues.
+func (op *Operation) Issues() []*Ydb_Issue.IssueMessage {
+	return op.issues
+}
+
+// Metadata returns script execution metadata, it is updated with every poll.
+func (op *Operation) Metadata() *Ydb_Query.ExecuteScriptMetadata {
+	return op.meta
+}
+
+// ExecStatus returns script execution status.
+func (op *Operation) ExecStatus() Ydb_Query.ExecStatus {
+	return op.meta.GetExecStatus()
+}
+
+// ResultSetsCount returns amount of script result sets.
+func (op *Operation) ResultSetsCount() int {
+	return len(op.meta.GetResultSetsMeta())
+}
+
+// Stats returns script stats. They are available after script is finished.
+func (op *Operation) Stats() *Ydb_TableStats.QueryStats {
+	return op.meta.GetExecStats()
+}
+
+// Err returns script error, if any.
+func (op *Operation) Err() error {
+	switch op.status {
+	case Ydb.StatusIds_SUCCESS, Ydb.StatusIds_STATUS_CODE_UNSPECIFIED:
+		return nil
+	default:
+		return ydberr.NewOperationError(op.status, op.issues)
+	}
+}
+
+// Poll requests current operation state. Script error is also returned.
+func (op *Operation) Poll(ctx context.Context) error {
+	err := op.client.retry(ctx, true, func(ctx context.Context) error {
+		resp, err := op.client.osc.GetOperation(ctx, &Ydb_Operations.GetOperationRequest{Id: op.id})
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		return op.update(resp.GetOperation())
+	})
+	if err != nil {
+		return errors.Join(ErrPoll, err)
+	}
+
+	return op.Err()
+}
+
+// Wait polls operation until script is finished or ctx is done.
+// Poll interval grows exponentially. Script error is returned.
+func (op *Operation) Wait(ctx context.Context) error {
+	interval := minPollInterval
+	for !op.ready {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err() //nolint:wrapcheck // unnecessary
+		case <-timer.C:
+		}
+
+		if err := op.Poll(ctx); err != nil {
+			return err
+		}
+
+		interval = min(interval*2, maxPollInterval)
+	}
+
+	return op.Err()
+}
+
+// Cancel starts script cancellation. Operation should be polled
+// to check whether script was canceled or finished despite cancellation.
+func (op *Operation) Cancel(ctx context.Context) error {
+	err := op.client.retry(ctx, true, func(ctx context.Context) error {
+		resp, err := op.client.osc.CancelOperation(ctx, &Ydb_Operations.CancelOperationRequest{Id: op.id})
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+		if resp.Status != Ydb.StatusIds_SUCCESS {
+			return ydberr.NewOperationError(resp.Status, resp.Issues)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrCancel, err)
+	}
+
+	return nil
+}
+
+// Forget deletes finished operation and its results.
+func (op *Operation) Forget(ctx context.Context) error {
+	err := op.client.retry(ctx, true, func(ctx context.Context) error {
+		resp, err := op.client.osc.ForgetOperation(ctx, &Ydb_Operations.ForgetOperationRequest{Id: op.id})
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+		if resp.Status != Ydb.StatusIds_SUCCESS {
+			return ydberr.NewOperationError(resp.Status, resp.Issues)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.Join(ErrForget, err)
+	}
+
+	return nil
+}
+
+// Fetch requests page of result set. Empty token requests the first page.
+// Zero rowsLimit means YDB default limit.
+func (op *Operation) Fetch(ctx context.Context, resultSetIndex int64, token string, rowsLimit int64) (*Page, error) {
+	var page *Page
+	err := op.client.retry(ctx, true, func(ctx context.Context) error {
+		resp, err := op.client.qsc.FetchScriptResults(ctx, &Ydb_Query.FetchScriptResultsRequest{
+			OperationId:    op.id,
+			ResultSetIndex: resultSetIndex,
+			FetchToken:     token,
+			RowsLimit:      rowsLimit,
+		})
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+		if resp.Status != Ydb.StatusIds_SUCCESS {
+			return ydberr.NewOperationError(resp.Status, resp.Issues)
+		}
+
+		page = &Page{
+			ResultSet: resp.ResultSet,
+			NextToken: resp.NextFetchToken,
+			Index:     resp.ResultSetIndex,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Join(ErrFetch, err)
+	}
+
+	return page, nil
+}
+
+// Pages iterates over all pages of result set.
+// Iteration stops after first error.
+func (op *Operation) Pages(ctx context.Context, resultSetIndex int64, pageSize int64) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		var token string
+		for {
+			page, err := op.Fetch(ctx, resultSetIndex, token, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || page.NextToken == "" {
+				return
+			}
+			token = page.NextToken
+		}
+	}
+}
+
+// Rows iterates over all rows of result set.
+// Iteration stops after first error.
+func (op *Operation) Rows(ctx context.Context, resultSetIndex int64, pageSize int64) iter.Seq2[*Ydb.Value, error] {
+	return func(yield func(*Ydb.Value, error) bool) {
+		for page, err := range op.Pages(ctx, resultSetIndex, pageSize) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, row := range page.ResultSet.GetRows() {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// update applies operation state received from YDB.
+func (op *Operation) update(opProto *Ydb_Operations.Operation) error {
+	op.ready = opProto.GetReady()
+	op.status = opProto.GetStatus()
+	op.issues = opProto.GetIssues()
+
+	if opProto.GetMetadata() == nil {
+		return nil
+	}
+
+	var meta Ydb_Query.ExecuteScriptMetadata
+	if err := opProto.GetMetadata().UnmarshalTo(&meta); err != nil {
+		return errors.Join(ErrMetadata, err)
+	}
+	op.meta = &meta
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package scripts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/logger"
+	"github.com/adwski/ydb-go-query/internal/logger/noop"
+	"github.com/adwski/ydb-go-query/ydberr"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Operation_V1"
+	"github.com/ydb-platform/ydb-go-genproto/Ydb_Query_V1"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type fakeQueryService struct {
+	Ydb_Query_V1.QueryServiceClient
+
+	execReq *Ydb_Query.ExecuteScriptRequest
+	pages   map[string]*Ydb_Query.FetchScriptResultsResponse // by fetch token
+}
+
+func (f *fakeQueryService) ExecuteScript(
+	_ context.Context,
+	req *Ydb_Query.ExecuteScriptRequest,
+	_ ...grpc.CallOption,
+) (*Ydb_Operations.Operation, error) {
+	f.execReq = req
+
+	return testOperation(false, Ydb_Query.ExecStatus_EXEC_STATUS_STARTING), nil
+}
+
+func (f *fakeQueryService) FetchScriptResults(
+	_ context.Context,
+	req *Ydb_Query.FetchScriptResultsRequest,
+	_ ...grpc.CallOption,
+) (*Ydb_Query.FetchScriptResultsResponse, error) {
+	return f.pages[req.FetchToken], nil
+}
+
+type fakeOperationService struct {
+	Ydb_Operation_V1.OperationServiceClient
+
+	ops      []*Ydb_Operations.Operation
+	polls    int
+	canceled bool
+}
+
+func (f *fakeOperationService) GetOperation(
+	context.Context,
+	*Ydb_Operations.GetOperationRequest,
+	...grpc.CallOption,
+) (*Ydb_Operations.GetOperationResponse, error) {
+	op := f.ops[min(f.polls, len(f.ops)-1)]
+	f.polls++
+
+	return &Ydb_Operations.GetOperationResponse{Operation: op}, nil
+}
+
+func (f *fakeOperationService) CancelOperation(
+	context.Context,
+	*Ydb_Operations.CancelOperationRequest,
+	...grpc.CallOption,
+) (*Ydb_Operations.CancelOperationResponse, error) {
+	f.canceled = true
+
+	return &Ydb_Operations.CancelOperationResponse{Status: Ydb.StatusIds_SUCCESS}, nil
+}
+
+func (f *fakeOperationService) ForgetOperation(
+	context.Context,
+	*Ydb_Operations.ForgetOperationRequest,
+	...grpc.CallOption,
+) (*Ydb_Operations.ForgetOperationResponse, error) {
+	return &Ydb_Operations.ForgetOperationResponse{Status: Ydb.StatusIds_NOT_FOUND}, nil
+}
+
+func testOperation(ready bool, execStatus Ydb_Query.ExecStatus) *Ydb_Operations.Operation {
+	meta, _ := anypb.New(&Ydb_Query.ExecuteScriptMetadata{
+		ExecutionId:    "exec-1",
+		ExecStatus:     execStatus,
+		ResultSetsMeta: []*Ydb_Query.ResultSetMeta{{}},
+	})
+
+	return &Ydb_Operations.Operation{
+		Id:       "op-1",
+		Ready:    ready,
+		Status:   Ydb.StatusIds_SUCCESS,
+		Metadata: meta,
+	}
+}
+
+func newTestClient(qsc Ydb_Query_V1.QueryServiceClient, osc Ydb_Operation_V1.OperationServiceClient) *Client {
+	return &Client{
+		logger:        logger.New(noop.NewLogger()),
+		qsc:           qsc,
+		osc:           osc,
+		retryAttempts: 1,
+	}
+}
+
+func uint64Rows(vals ...uint64) []*Ydb.Value {
+	rows := make([]*Ydb.Value, 0, len(vals))
+	for _, v := range vals {
+		rows = append(rows, &Ydb.Value{Items: []*Ydb.Value{{Value: &Ydb.Value_Uint64Value{Uint64Value: v}}}})
+	}
+
+	return rows
+}
+
+func TestScript_ExecuteAndWait(t *testing.T) {
+	qsc := &fakeQueryService{}
+	osc := &fakeOperationService{ops: []*Ydb_Operations.Operation{
+		testOperation(false, Ydb_Query.ExecStatus_EXEC_STATUS_STARTING),
+		testOperation(true, Ydb_Query.ExecStatus_EXEC_STATUS_COMPLETED),
+	}}
+	client := newTestClient(qsc, osc)
+
+	op, err := client.Script("SELECT 1").
+		Param("$id", &Ydb.TypedValue{}).
+		ResultsTTL(time.Hour).
+		Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "op-1", op.ID())
+	assert.False(t, op.Ready())
+	assert.Equal(t, Ydb_Query.ExecStatus_EXEC_STATUS_STARTING, op.ExecStatus())
+	assert.Equal(t, "SELECT 1", qsc.execReq.ScriptContent.Text)
+	assert.Equal(t, int64(3600), qsc.execReq.ResultsTtl.Seconds)
+	assert.Contains(t, qsc.execReq.Parameters, "$id")
+
+	require.NoError(t, op.Wait(context.Background()))
+	assert.True(t, op.Ready())
+	assert.Equal(t, 2, osc.polls)
+	assert.Equal(t, Ydb_Query.ExecStatus_EXEC_STATUS_COMPLETED, op.ExecStatus())
+	assert.Equal(t, 1, op.ResultSetsCount())
+
+	require.NoError(t, op.Cancel(context.Background()))
+	assert.True(t, osc.canceled)
+
+	err = op.Forget(context.Background())
+	require.ErrorIs(t, err, ErrForget)
+	assert.True(t, ydberr.IsNotFound(err))
+}
+
+func TestOperation_PollFailed(t *testing.T) {
+	failed := testOperation(true, Ydb_Query.ExecStatus_EXEC_STATUS_FAILED)
+	failed.Status = Ydb.StatusIds_GENERIC_ERROR
+
+	op := newTestClient(nil, &fakeOperationService{ops: []*Ydb_Operations.Operation{failed}}).Operation("op-1")
+	err := op.Poll(context.Background())
+	assert.True(t, ydberr.IsStatus(err, Ydb.StatusIds_GENERIC_ERROR))
+	assert.True(t, op.Ready())
+	assert.Equal(t, err, op.Err())
+}
+
+func TestOperation_Rows(t *testing.T) {
+	qsc := &fakeQueryService{pages: map[string]*Ydb_Query.FetchScriptResultsResponse{
+		"": {
+			Status:         Ydb.StatusIds_SUCCESS,
+			ResultSet:      &Ydb.ResultSet{Rows: uint64Rows(1, 2)},
+			NextFetchToken: "next",
+		},
+		"next": {
+			Status:    Ydb.StatusIds_SUCCESS,
+			ResultSet: &Ydb.ResultSet{Rows: uint64Rows(3)},
+		},
+	}}
+	op := newTestClient(qsc, nil).Operation("op-1")
+
+	var vals []uint64
+	for row, err := range op.Rows(context.Background(), 0, 2) {
+		require.NoError(t, err)
+		vals = append(vals, row.Items[0].GetUint64Value())
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, vals)
+
+	var pages int
+	for _, err := range op.Pages(context.Background(), 0, 2) {
+		require.NoError(t, err)
+		pages++
+		break
+	}
+	assert.Equal(t, 1, pages)
+
+	qsc.pages["next"].Status = Ydb.StatusIds_BAD_REQUEST
+	var errs int
+	for _, err := range op.Rows(context.Background(), 0, 2) {
+		if err != nil {
+			require.ErrorIs(t, err, ErrFetch)
+			errs++
+		}
+	}
+	assert.Equal(t, 1, errs)
+}
@@ -0,0 +1,240 @@
+package types
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// Check validates that value of tv matches its declared type. Errors are wrapped
+// with path to mismatched value, for example [2]: name: type mismatch: Uint64Value, expected Utf8.
+func Check(tv *Ydb.TypedValue) error {
+	if tv.GetType() == nil {
+		return fmt.Errorf("%w: type is not set", ErrMismatch)
+	}
+
+	return CheckValue(tv.GetType(), tv.GetValue())
+}
+
+// CheckValue validates that val matches typ.
+//
+//nolint:cyclop,gocognit // flat switch over type kinds
+func CheckValue(typ *Ydb.Type, val *Ydb.Value) error {
+	if val == nil {
+		return fmt.Errorf("%w: value is not set, expected %s", ErrMismatch, FormatType(typ))
+	}
+
+	switch t := typ.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		return checkPrimitive(typ, t.TypeId, val)
+	case *Ydb.Type_DecimalType:
+		if _, ok := val.GetValue().(*Ydb.Value_Low_128); !ok {
+			return valueMismatch(typ, val)
+		}
+	case *Ydb.Type_OptionalType:
+		switch v := val.GetValue().(type) {
+		case *Ydb.Value_NullFlagValue:
+			return nil
+		case *Ydb.Value_NestedValue:
+			if _, nested := t.OptionalType.GetItem().GetType().(*Ydb.Type_OptionalType); nested {
+				return CheckValue(t.OptionalType.GetItem(), v.NestedValue)
+			}
+		}
+		if _, nested := t.OptionalType.GetItem().GetType().(*Ydb.Type_OptionalType); nested {
+			return valueMismatch(typ, val)
+		}
+
+		return CheckValue(t.OptionalType.GetItem(), val)
+	case *Ydb.Type_ListType:
+		for i, item := range val.GetItems() {
+			if err := CheckValue(t.ListType.GetItem(), item); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	case *Ydb.Type_TupleType:
+		elements := t.TupleType.GetElements()
+		if len(val.GetItems()) != len(elements) {
+			return fmt.Errorf("%w: %d items, expected %s", ErrMismatch, len(val.GetItems()), FormatType(typ))
+		}
+		for i, item := range val.GetItems() {
+			if err := CheckValue(elements[i], item); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	case *Ydb.Type_StructType:
+		members := t.StructType.GetMembers()
+		if len(val.GetItems()) != len(members) {
+			return fmt.Errorf("%w: %d members, expected %s", ErrMismatch, len(val.GetItems()), FormatType(typ))
+		}
+		for i, item := range val.GetItems() {
+			if err := CheckValue(members[i].GetType(), item); err != nil {
+				return fmt.Errorf("%s: %w", members[i].GetName(), err)
+			}
+		}
+	case *Ydb.Type_DictType:
+		for i, pair := range val.GetPairs() {
+			if err := CheckValue(t.DictType.GetKey(), pair.GetKey()); err != nil {
+				return fmt.Errorf("[%d] key: %w", i, err)
+			}
+			if err := CheckValue(t.DictType.GetPayload(), pair.GetPayload()); err != nil {
+				return fmt.Errorf("[%d] payload: %w", i, err)
+			}
+		}
+	case *Ydb.Type_VariantType:
+		return checkVariant(typ, t.VariantType, val)
+	case *Ydb.Type_TaggedType:
+		return CheckValue(t.TaggedType.GetType(), val)
+	case *Ydb.Type_VoidType, *Ydb.Type_NullType:
+		if _, ok := val.GetValue().(*Ydb.Value_NullFlagValue); !ok {
+			return valueMismatch(typ, val)
+		}
+	case *Ydb.Type_EmptyListType, *Ydb.Type_EmptyDictType:
+		if len(val.GetItems()) > 0 || len(val.GetPairs()) > 0 {
+			return fmt.Errorf("%w: %s is not empty", ErrMismatch, FormatType(typ))
+		}
+	case *Ydb.Type_PgType:
+		switch val.GetValue().(type) {
+		case *Ydb.Value_TextValue, *Ydb.Value_BytesValue, *Ydb.Value_NullFlagValue:
+		default:
+			return valueMismatch(typ, val)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type", ErrMismatch)
+	}
+
+	return nil
+}
+
+func checkVariant(typ *Ydb.Type, vt *Ydb.VariantType, val *Ydb.Value) error {
+	var (
+		alternatives []*Ydb.Type
+		names        []string
+	)
+	switch items := vt.GetType().(type) {
+	case *Ydb.VariantType_TupleItems:
+		alternatives = items.TupleItems.GetElements()
+	case *Ydb.VariantType_StructItems:
+		for _, m := range items.StructItems.GetMembers() {
+			alternatives = append(alternatives, m.GetType())
+			names = append(names, m.GetName())
+		}
+	}
+
+	idx := val.GetVariantIndex()
+	if int(idx) >= len(alternatives) {
+		return fmt.Errorf("%w: index %d, expected %s", ErrVariant, idx, FormatType(typ))
+	}
+	nested, ok := val.GetValue().(*Ydb.Value_NestedValue)
+	if !ok {
+		return valueMismatch(typ, val)
+	}
+	if err := CheckValue(alternatives[idx], nested.NestedValue); err != nil {
+		if names != nil {
+			return fmt.Errorf("%s: %w", names[idx], err)
+		}
+
+		return fmt.Errorf("[%d]: %w", idx, err)
+	}
+
+	return nil
+}
+
+//nolint:cyclop // flat switch over primitive types
+func checkPrimitive(typ *Ydb.Type, id Ydb.Type_PrimitiveTypeId, val *Ydb.Value) error {
+	var ok bool
+	switch v := val.GetValue().(type) {
+	case *Ydb.Value_BoolValue:
+		ok = id == Ydb.Type_BOOL
+	case *Ydb.Value_Int32Value:
+		switch id { //nolint:exhaustive // other types are not Int32Value
+		case Ydb.Type_INT8:
+			ok = v.Int32Value >= math.MinInt8 && v.Int32Value <= math.MaxInt8
+		case Ydb.Type_INT16:
+			ok = v.Int32Value >= math.MinInt16 && v.Int32Value <= math.MaxInt16
+		case Ydb.Type_INT32, TypeDate32:
+			ok = true
+		}
+	case *Ydb.Value_Uint32Value:
+		switch id { //nolint:exhaustive // other types are not Uint32Value
+		case Ydb.Type_UINT8:
+			ok = v.Uint32Value <= math.MaxUint8
+		case Ydb.Type_UINT16:
+			ok = v.Uint32Value <= math.MaxUint16
+		case Ydb.Type_UINT32, Ydb.Type_DATE, Ydb.Type_DATETIME:
+			ok = true
+		}
+	case *Ydb.Value_Int64Value:
+		switch id { //nolint:exhaustive // other types are not Int64Value
+		case Ydb.Type_INT64, Ydb.Type_INTERVAL, TypeDatetime64, TypeTimestamp64, TypeInterval64:
+			ok = true
+		}
+	case *Ydb.Value_Uint64Value:
+		ok = id == Ydb.Type_UINT64 || id == Ydb.Type_TIMESTAMP
+	case *Ydb.Value_FloatValue:
+		ok = id == Ydb.Type_FLOAT
+	case *Ydb.Value_DoubleValue:
+		ok = id == Ydb.Type_DOUBLE
+	case *Ydb.Value_TextValue:
+		switch id { //nolint:exhaustive // other types are not TextValue
+		case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER,
+			Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP:
+			ok = true
+		}
+	case *Ydb.Value_BytesValue:
+		ok = id == Ydb.Type_STRING || id == Ydb.Type_YSON
+	case *Ydb.Value_Low_128:
+		ok = id == Ydb.Type_UUID
+	}
+
+	if !ok {
+		return valueMismatch(typ, val)
+	}
+
+	return nil
+}
+
+func valueMismatch(typ *Ydb.Type, val *Ydb.Value) error {
+	return fmt.Errorf("%w: %s, expected %s", ErrMismatch, valueKind(val), FormatType(typ))
+}
+
+// valueKind returns name of value oneof, like Uint64Value.
+func valueKind(val *Ydb.Value) string {
+	switch val.GetValue().(type) {
+	case *Ydb.Value_BoolValue:
+		return "BoolValue"
+	case *Ydb.Value_Int32Value:
+		return "Int32Value"
+	case *Ydb.Value_Uint32Value:
+		return "Uint32Value"
+	case *Ydb.Value_Int64Value:
+		return "Int64Value"
+	case *Ydb.Value_Uint64Value:
+		return "Uint64Value"
+	case *Ydb.Value_FloatValue:
+		return "FloatValue"
+	case *Ydb.Value_DoubleValue:
+		return "DoubleValue"
+	case *Ydb.Value_BytesValue:
+		return "BytesValue"
+	case *Ydb.Value_TextValue:
+		return "TextValue"
+	case *Ydb.Value_NullFlagValue:
+		return "NullFlagValue"
+	case *Ydb.Value_NestedValue:
+		return "NestedValue"
+	case *Ydb.Value_Low_128:
+		return "Low128Value"
+	case nil:
+		if len(val.GetItems()) > 0 {
+			return "Items"
+		}
+		if len(val.GetPairs()) > 0 {
+			return "Pairs"
+		}
+
+		return "empty value"
+	default:
+		return unknownType
+	}
+}
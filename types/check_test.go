@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestCheck(t *testing.T) {
+	rows, err := List(
+		Struct(Field("id", Uint64(1)), Field("name", Optional(UTF8("a")))),
+		Struct(Field("id", Uint64(2)), Field("name", NullOf(UTF8("").Type))),
+	)
+	require.NoError(t, err)
+	variant, err := VariantTuple(1, UTF8("a"), Uint64(0).Type, UTF8("").Type)
+	require.NoError(t, err)
+	dict, err := Dict(Pair(UTF8("a"), Optional(Optional(Int32(1)))))
+	require.NoError(t, err)
+	dec, err := DecimalFromString("1.5", 22, 2)
+	require.NoError(t, err)
+
+	for _, tv := range []*Ydb.TypedValue{
+		rows, variant, dict, dec, Tuple(Bool(true), Text("a")), UUID([16]byte{}), Interval64(1), null(),
+	} {
+		require.NoError(t, Check(tv), FormatType(tv.Type))
+	}
+
+	// Int8 out of range
+	err = Check(&Ydb.TypedValue{Type: Int32(0).Type, Value: Int32(1).Value})
+	require.NoError(t, err)
+	err = CheckValue(&Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_INT8}}, Int32(300).Value)
+	require.ErrorIs(t, err, ErrMismatch)
+
+	// mislabeled member
+	rows.Value.Items[1].Items[1] = Uint64(1).Value
+	err = Check(rows)
+	require.ErrorIs(t, err, ErrMismatch)
+	assert.Equal(t, "[1]: name: type mismatch: Uint64Value, expected Utf8", err.Error())
+
+	// missing nested value of optional of optional
+	dict.Value.Pairs[0].Payload = Int32(1).Value
+	require.ErrorIs(t, Check(dict), ErrMismatch)
+
+	variant.Value.VariantIndex = 3
+	require.ErrorIs(t, Check(variant), ErrVariant)
+
+	require.ErrorIs(t, Check(&Ydb.TypedValue{Type: Tuple(Bool(true)).Type, Value: &Ydb.Value{}}), ErrMismatch)
+	require.ErrorIs(t, Check(&Ydb.TypedValue{Value: &Ydb.Value{}}), ErrMismatch)
+}
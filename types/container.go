@@ -0,0 +1,218 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrMismatch = errors.New("type mismatch")
+	ErrVariant  = errors.New("invalid variant")
+)
+
+type (
+	// FieldValue is a named member of Struct value.
+	FieldValue struct {
+		Value *Ydb.TypedValue
+		Name  string
+	}
+
+	// PairValue is a key-payload pair of Dict value.
+	PairValue struct {
+		Key     *Ydb.TypedValue
+		Payload *Ydb.TypedValue
+	}
+)
+
+// Field creates Struct member with provided name and value.
+func Field(name string, v *Ydb.TypedValue) FieldValue {
+	return FieldValue{Name: name, Value: v}
+}
+
+// Pair creates Dict pair with provided key and payload.
+func Pair(key, payload *Ydb.TypedValue) PairValue {
+	return PairValue{Key: key, Payload: payload}
+}
+
+// Optional wraps v into Optional type. If v is Optional itself,
+// its value is nested, so NULL of inner Optional is distinguishable from outer NULL.
+func Optional(v *Ydb.TypedValue) *Ydb.TypedValue {
+	val := v.GetValue()
+	if _, nested := v.GetType().GetType().(*Ydb.Type_OptionalType); nested {
+		val = &Ydb.Value{Value: &Ydb.Value_NestedValue{NestedValue: v.GetValue()}}
+	}
+
+	return &Ydb.TypedValue{
+		Type:  optionalType(v.GetType()),
+		Value: val,
+	}
+}
+
+// NullOf creates NULL value of Optional<typ> type.
+func NullOf(typ *Ydb.Type) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  optionalType(typ),
+		Value: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}},
+	}
+}
+
+// List creates List value with item type derived from the first item.
+// All items must be of the same type. List without items has EmptyList type,
+// use ListOf to create empty list of specific type.
+func List(items ...*Ydb.TypedValue) (*Ydb.TypedValue, error) {
+	if len(items) == 0 {
+		return &Ydb.TypedValue{
+			Type:  &Ydb.Type{Type: &Ydb.Type_EmptyListType{}},
+			Value: &Ydb.Value{},
+		}, nil
+	}
+
+	return ListOf(items[0].GetType(), items...)
+}
+
+// ListOf creates List value with provided item type.
+func ListOf(itemType *Ydb.Type, items ...*Ydb.TypedValue) (*Ydb.TypedValue, error) {
+	values := make([]*Ydb.Value, 0, len(items))
+	for i, item := range items {
+		if err := sameType(itemType, item.GetType()); err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		values = append(values, item.GetValue())
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{Item: itemType}}},
+		Value: &Ydb.Value{Items: values},
+	}, nil
+}
+
+// Struct creates Struct value with members in order of fields.
+func Struct(fields ...FieldValue) *Ydb.TypedValue {
+	members := make([]*Ydb.StructMember, 0, len(fields))
+	items := make([]*Ydb.Value, 0, len(fields))
+	for _, f := range fields {
+		members = append(members, &Ydb.StructMember{Name: f.Name, Type: f.Value.GetType()})
+		items = append(items, f.Value.GetValue())
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{Members: members}}},
+		Value: &Ydb.Value{Items: items},
+	}
+}
+
+// Tuple creates Tuple value with elements in order of items.
+func Tuple(items ...*Ydb.TypedValue) *Ydb.TypedValue {
+	elements := make([]*Ydb.Type, 0, len(items))
+	values := make([]*Ydb.Value, 0, len(items))
+	for _, item := range items {
+		elements = append(elements, item.GetType())
+		values = append(values, item.GetValue())
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: elements}}},
+		Value: &Ydb.Value{Items: values},
+	}
+}
+
+// Dict creates Dict value with key and payload types derived from the first pair.
+// All pairs must be of the same types. Dict without pairs has EmptyDict type,
+// use DictOf to create empty dict of specific type.
+func Dict(pairs ...PairValue) (*Ydb.TypedValue, error) {
+	if len(pairs) == 0 {
+		return &Ydb.TypedValue{
+			Type:  &Ydb.Type{Type: &Ydb.Type_EmptyDictType{}},
+			Value: &Ydb.Value{},
+		}, nil
+	}
+
+	return DictOf(pairs[0].Key.GetType(), pairs[0].Payload.GetType(), pairs...)
+}
+
+// DictOf creates Dict value with provided key and payload types.
+func DictOf(keyType, payloadType *Ydb.Type, pairs ...PairValue) (*Ydb.TypedValue, error) {
+	values := make([]*Ydb.ValuePair, 0, len(pairs))
+	for i, p := range pairs {
+		if err := sameType(keyType, p.Key.GetType()); err != nil {
+			return nil, fmt.Errorf("[%d] key: %w", i, err)
+		}
+		if err := sameType(payloadType, p.Payload.GetType()); err != nil {
+			return nil, fmt.Errorf("[%d] payload: %w", i, err)
+		}
+		values = append(values, &Ydb.ValuePair{Key: p.Key.GetValue(), Payload: p.Payload.GetValue()})
+	}
+
+	return &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{
+			Key:     keyType,
+			Payload: payloadType,
+		}}},
+		Value: &Ydb.Value{Pairs: values},
+	}, nil
+}
+
+// VariantTuple creates Variant value over tuple of alternatives.
+// Value v must be of type of alternative with provided index.
+func VariantTuple(index uint32, v *Ydb.TypedValue, alternatives ...*Ydb.Type) (*Ydb.TypedValue, error) {
+	if int(index) >= len(alternatives) {
+		return nil, fmt.Errorf("%w: index %d, %d alternatives", ErrVariant, index, len(alternatives))
+	}
+	if err := sameType(alternatives[index], v.GetType()); err != nil {
+		return nil, fmt.Errorf("[%d]: %w", index, err)
+	}
+
+	return &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+			Type: &Ydb.VariantType_TupleItems{TupleItems: &Ydb.TupleType{Elements: alternatives}},
+		}}},
+		Value: variantValue(index, v.GetValue()),
+	}, nil
+}
+
+// VariantStruct creates Variant value over struct of named alternatives.
+// Value v must be of type of alternative with provided name.
+func VariantStruct(name string, v *Ydb.TypedValue, alternatives ...*Ydb.StructMember) (*Ydb.TypedValue, error) {
+	index := -1
+	for i, m := range alternatives {
+		if m.GetName() == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("%w: no alternative %q", ErrVariant, name)
+	}
+	if err := sameType(alternatives[index].GetType(), v.GetType()); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+			Type: &Ydb.VariantType_StructItems{StructItems: &Ydb.StructType{Members: alternatives}},
+		}}},
+		Value: variantValue(uint32(index), v.GetValue()), //nolint:gosec // index is within slice
+	}, nil
+}
+
+func variantValue(index uint32, v *Ydb.Value) *Ydb.Value {
+	return &Ydb.Value{
+		Value:        &Ydb.Value_NestedValue{NestedValue: v},
+		VariantIndex: index,
+	}
+}
+
+func optionalType(item *Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_OptionalType{OptionalType: &Ydb.OptionalType{Item: item}}}
+}
+
+func sameType(want, got *Ydb.Type) error {
+	if !proto.Equal(want, got) {
+		return fmt.Errorf("%w: %s, expected %s", ErrMismatch, FormatType(got), FormatType(want))
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestContainers(t *testing.T) {
+	rows, err := List(
+		Struct(Field("id", Uint64(1)), Field("name", Optional(UTF8("a")))),
+		Struct(Field("id", Uint64(2)), Field("name", NullOf(UTF8("").Type))),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "List<Struct<id:Uint64,name:Optional<Utf8>>>", FormatType(rows.Type))
+	require.Len(t, rows.Value.Items, 2)
+	assert.Equal(t, "a", rows.Value.Items[0].Items[1].GetTextValue())
+	assert.Equal(t, &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}, rows.Value.Items[1].Items[1])
+
+	_, err = List(Uint64(1), UTF8("a"))
+	require.ErrorIs(t, err, ErrMismatch)
+	assert.Contains(t, err.Error(), "[1]: type mismatch: Utf8, expected Uint64")
+
+	empty, err := List()
+	require.NoError(t, err)
+	assert.Equal(t, "EmptyList", FormatType(empty.Type))
+
+	empty, err = ListOf(Int32(0).Type)
+	require.NoError(t, err)
+	assert.Equal(t, "List<Int32>", FormatType(empty.Type))
+
+	dict, err := Dict(Pair(UTF8("x"), Int32(1)), Pair(UTF8("y"), Int32(2)))
+	require.NoError(t, err)
+	assert.Equal(t, "Dict<Utf8,Int32>", FormatType(dict.Type))
+	assert.Len(t, dict.Value.Pairs, 2)
+
+	_, err = Dict(Pair(UTF8("x"), Int32(1)), Pair(UTF8("y"), Int64(2)))
+	require.ErrorIs(t, err, ErrMismatch)
+
+	tuple := Tuple(Bool(true), Double(1.5))
+	assert.Equal(t, "Tuple<Bool,Double>", FormatType(tuple.Type))
+	assert.Len(t, tuple.Value.Items, 2)
+}
+
+func TestOptional_Nested(t *testing.T) {
+	inner := NullOf(Int32(0).Type)
+	outer := Optional(inner)
+	assert.Equal(t, "Optional<Optional<Int32>>", FormatType(outer.Type))
+	assert.Equal(t, &Ydb.Value{Value: &Ydb.Value_NestedValue{NestedValue: inner.Value}}, outer.Value)
+
+	outer = Optional(Optional(Int32(5)))
+	assert.Equal(t, int32(5), outer.Value.GetNestedValue().GetInt32Value())
+
+	null := NullOf(outer.Type)
+	assert.Equal(t, "Optional<Optional<Optional<Int32>>>", FormatType(null.Type))
+	assert.Equal(t, &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}, null.Value)
+}
+
+func TestVariant(t *testing.T) {
+	v, err := VariantTuple(1, UTF8("a"), Uint64(0).Type, UTF8("").Type)
+	require.NoError(t, err)
+	assert.Equal(t, "Variant<Uint64,Utf8>", FormatType(v.Type))
+	assert.Equal(t, uint32(1), v.Value.VariantIndex)
+	assert.Equal(t, "a", v.Value.GetNestedValue().GetTextValue())
+
+	_, err = VariantTuple(0, UTF8("a"), Uint64(0).Type, UTF8("").Type)
+	require.ErrorIs(t, err, ErrMismatch)
+	_, err = VariantTuple(2, UTF8("a"), Uint64(0).Type, UTF8("").Type)
+	require.ErrorIs(t, err, ErrVariant)
+
+	alternatives := []*Ydb.StructMember{
+		{Name: "ok", Type: Uint64(0).Type},
+		{Name: "err", Type: UTF8("").Type},
+	}
+	v, err = VariantStruct("err", UTF8("failed"), alternatives...)
+	require.NoError(t, err)
+	assert.Equal(t, "Variant<ok:Uint64,err:Utf8>", FormatType(v.Type))
+	assert.Equal(t, uint32(1), v.Value.VariantIndex)
+
+	_, err = VariantStruct("unknown", UTF8("failed"), alternatives...)
+	require.ErrorIs(t, err, ErrVariant)
+}
@@ -0,0 +1,627 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/naming"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	tagName   = "ydb"
+	tagType   = "type="
+	maxDepth  = 32
+	secPerDay = 86400
+)
+
+var (
+	ErrGoType = errors.New("unsupported Go type")
+)
+
+var (
+	typeTime          = reflect.TypeFor[time.Time]()
+	typeDuration      = reflect.TypeFor[time.Duration]()
+	typeRawMessage    = reflect.TypeFor[json.RawMessage]()
+	typeTypedValue    = reflect.TypeFor[*Ydb.TypedValue]()
+	typeValueMarshal  = reflect.TypeFor[ValueMarshaler]()
+	typeDriverValuer  = reflect.TypeFor[driver.Valuer]()
+	primitiveByName   = reversePrimitiveNames()
+	errRecursiveDepth = errors.New("value is too deep")
+)
+
+type (
+	// ValueMarshaler is implemented by types which can convert themselves to YDB value.
+	ValueMarshaler interface {
+		MarshalYDB() (*Ydb.TypedValue, error)
+	}
+
+	// field is a struct field converted to Struct member or query parameter.
+	field struct {
+		name  string
+		index []int
+		// override is a primitive type set with type= tag option
+		override Ydb.Type_PrimitiveTypeId
+	}
+)
+
+// FromGo converts Go value to YDB value:
+//   - bool to Bool
+//   - intN and uintN to IntN and UintN, int and uint to Int64 and Uint64
+//   - float32 and float64 to Float and Double
+//   - string to Utf8, []byte to String, json.RawMessage to Json, [16]byte to Uuid
+//   - time.Time to Timestamp, time.Duration to Interval, zero time.Time is converted to Unix epoch
+//   - pointers to Optional, nil pointers to NULL of corresponding Optional type
+//   - slices to List, maps to Dict, structs to Struct
+//   - items of interface slices and maps, such as []any, must be non-empty and have the same type
+//   - untyped nil to Null
+//
+// Values of named types are converted according to their underlying types.
+// ValueMarshaler and driver.Valuer implementations are converted using returned value.
+//
+// Struct members are named after `ydb:"name"` tag, fields without tag are named
+// in snake_case. Fields tagged with `ydb:"-"` and unexported fields are skipped,
+// embedded structs are flattened. Member type can be overridden
+// with type option, for example `ydb:"created,type=Datetime"`.
+func FromGo(v any) (*Ydb.TypedValue, error) {
+	if v == nil {
+		return null(), nil
+	}
+
+	return fromValue(reflect.ValueOf(v), Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED, 0)
+}
+
+// ParamsFromStruct converts exported fields of struct to query parameters.
+// Parameters are named as $name, where name is derived from field the same way as
+// member names in FromGo. Pointer to struct is also accepted.
+func ParamsFromStruct(v any) (map[string]*Ydb.TypedValue, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("%w: nil %s", ErrGoType, rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrGoType, v)
+	}
+
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]*Ydb.TypedValue, len(fields))
+	for _, f := range fields {
+		val, errF := fromValue(fieldByIndex(rv, f.index), f.override, 1)
+		if errF != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, errF)
+		}
+		params["$"+f.name] = val
+	}
+
+	return params, nil
+}
+
+func fromValue(rv reflect.Value, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.TypedValue, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("%w: %w", ErrGoType, errRecursiveDepth)
+	}
+
+	if tv, ok, err := marshal(rv, override, depth); ok {
+		return tv, err
+	}
+
+	switch rv.Kind() { //nolint:exhaustive // primitive kinds are handled below
+	case reflect.Pointer:
+		return optional(rv, override, depth)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return null(), nil
+		}
+
+		return fromValue(rv.Elem(), override, depth+1)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() != reflect.Uint8 {
+			return list(rv, override, depth)
+		}
+	case reflect.Map:
+		return dict(rv, override, depth)
+	case reflect.Struct:
+		if rv.Type() != typeTime {
+			return structValue(rv, depth)
+		}
+	}
+
+	id := override
+	if id == Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED {
+		id = defaultPrimitive(rv)
+	}
+
+	return primitiveFromGo(rv, id)
+}
+
+// marshal converts value with ValueMarshaler or driver.Valuer implementation.
+func marshal(rv reflect.Value, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.TypedValue, bool, error) {
+	if !rv.IsValid() {
+		return null(), true, nil
+	}
+
+	t := rv.Type()
+	if t == typeTypedValue && !rv.IsNil() {
+		return rv.Interface().(*Ydb.TypedValue), true, nil //nolint:forcetypeassert // checked above
+	}
+
+	if t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(typeValueMarshal) {
+		// pointer receiver
+		if rv.CanAddr() {
+			rv = rv.Addr()
+		} else {
+			ptr := reflect.New(t)
+			ptr.Elem().Set(rv)
+			rv = ptr
+		}
+		t = rv.Type()
+	}
+
+	if t.Implements(typeValueMarshal) {
+		if t.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, false, nil
+		}
+		tv, err := rv.Interface().(ValueMarshaler).MarshalYDB() //nolint:forcetypeassert // checked above
+		if err != nil {
+			return nil, true, err //nolint:wrapcheck // user defined error
+		}
+
+		return tv, true, nil
+	}
+
+	if t.Implements(typeDriverValuer) {
+		if t.Kind() == reflect.Pointer && rv.IsNil() {
+			return null(), true, nil
+		}
+		dv, err := rv.Interface().(driver.Valuer).Value() //nolint:forcetypeassert // checked above
+		if err != nil {
+			return nil, true, err //nolint:wrapcheck // user defined error
+		}
+		if dv == nil {
+			return null(), true, nil
+		}
+		tv, err := fromValue(reflect.ValueOf(dv), override, depth+1)
+
+		return tv, true, err
+	}
+
+	return nil, false, nil
+}
+
+func optional(rv reflect.Value, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.TypedValue, error) {
+	if rv.IsNil() {
+		item, err := fromValue(reflect.Zero(rv.Type().Elem()), override, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return NullOf(item.Type), nil
+	}
+
+	item, err := fromValue(rv.Elem(), override, depth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return Optional(item), nil
+}
+
+func list(rv reflect.Value, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.TypedValue, error) {
+	items := make([]*Ydb.Value, 0, rv.Len())
+	itemTypes := make([]*Ydb.Type, 0, rv.Len())
+	for i := range rv.Len() {
+		item, errI := fromValue(rv.Index(i), override, depth+1)
+		if errI != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, errI)
+		}
+		items = append(items, item.Value)
+		itemTypes = append(itemTypes, item.Type)
+	}
+
+	itemType, err := elemType(rv.Type().Elem(), itemTypes, override, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{Item: itemType}}},
+		Value: &Ydb.Value{Items: items},
+	}, nil
+}
+
+// dict converts map to Dict. Pairs are sorted by key to keep values deterministic.
+func dict(rv reflect.Value, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.TypedValue, error) {
+	keys := rv.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	})
+
+	pairs := make([]*Ydb.ValuePair, 0, len(keys))
+	keyTypes := make([]*Ydb.Type, 0, len(keys))
+	payloadTypes := make([]*Ydb.Type, 0, len(keys))
+	for _, key := range keys {
+		k, errK := fromValue(key, Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED, depth+1)
+		if errK != nil {
+			return nil, fmt.Errorf("[%v]: %w", key, errK)
+		}
+		p, errP := fromValue(rv.MapIndex(key), override, depth+1)
+		if errP != nil {
+			return nil, fmt.Errorf("[%v]: %w", key, errP)
+		}
+		pairs = append(pairs, &Ydb.ValuePair{Key: k.Value, Payload: p.Value})
+		keyTypes = append(keyTypes, k.Type)
+		payloadTypes = append(payloadTypes, p.Type)
+	}
+
+	keyType, err := elemType(rv.Type().Key(), keyTypes, Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED, depth)
+	if err != nil {
+		return nil, fmt.Errorf("key: %w", err)
+	}
+	payloadType, err := elemType(rv.Type().Elem(), payloadTypes, override, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{
+			Key:     keyType,
+			Payload: payloadType,
+		}}},
+		Value: &Ydb.Value{Pairs: pairs},
+	}, nil
+}
+
+func structValue(rv reflect.Value, depth int) (*Ydb.TypedValue, error) {
+	fields, err := structFields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*Ydb.StructMember, 0, len(fields))
+	items := make([]*Ydb.Value, 0, len(fields))
+	for _, f := range fields {
+		val, errF := fromValue(fieldByIndex(rv, f.index), f.override, depth+1)
+		if errF != nil {
+			return nil, fmt.Errorf("%s: %w", f.name, errF)
+		}
+		members = append(members, &Ydb.StructMember{Name: f.name, Type: val.Type})
+		items = append(items, val.Value)
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{Members: members}}},
+		Value: &Ydb.Value{Items: items},
+	}, nil
+}
+
+// elemType returns item type of container with Go element type t and converted items of itemTypes.
+// Type of interface elements cannot be derived from Go type, so it is taken from items
+// which must all have the same type.
+func elemType(t reflect.Type, itemTypes []*Ydb.Type, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.Type, error) {
+	if t.Kind() != reflect.Interface {
+		return typeOf(t, override, depth)
+	}
+	if len(itemTypes) == 0 {
+		return nil, fmt.Errorf("%w: cannot derive item type of empty container of %s", ErrGoType, t)
+	}
+	for i, it := range itemTypes[1:] {
+		if !proto.Equal(itemTypes[0], it) {
+			return nil, fmt.Errorf("%w: item %d has type %s, expected %s",
+				ErrGoType, i+1, FormatType(it), FormatType(itemTypes[0]))
+		}
+	}
+
+	return itemTypes[0], nil
+}
+
+// typeOf returns YDB type of Go type. It is needed for empty containers and NULL values.
+func typeOf(t reflect.Type, override Ydb.Type_PrimitiveTypeId, depth int) (*Ydb.Type, error) {
+	tv, err := fromValue(reflect.Zero(t), override, depth+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return tv.Type, nil
+}
+
+// fieldByIndex returns struct field by index path.
+// Fields of nil embedded pointers are returned as zero values.
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, idx := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				rv = reflect.Zero(rv.Type().Elem())
+			} else {
+				rv = rv.Elem()
+			}
+		}
+		rv = rv.Field(idx)
+	}
+
+	return rv
+}
+
+func structFields(t reflect.Type) ([]field, error) {
+	return collectFields(t, nil)
+}
+
+func collectFields(t reflect.Type, parent []int) ([]field, error) {
+	var fields []field
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		index := append(append([]int(nil), parent...), i)
+
+		name, opts, _ := strings.Cut(sf.Tag.Get(tagName), ",")
+		if name == "-" {
+			continue
+		}
+
+		if sf.Anonymous && name == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && ft != typeTime {
+				embedded, err := collectFields(ft, index)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, embedded...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := field{name: name, index: index}
+		if f.name == "" {
+			f.name = naming.Snake(sf.Name)
+		}
+
+		for _, opt := range strings.Split(opts, ",") {
+			typeName, ok := strings.CutPrefix(opt, tagType)
+			if !ok {
+				continue
+			}
+			if f.override, ok = primitiveByName[typeName]; !ok {
+				return nil, fmt.Errorf("%w: %s: unknown type %q", ErrGoType, sf.Name, typeName)
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+func defaultPrimitive(rv reflect.Value) Ydb.Type_PrimitiveTypeId {
+	switch rv.Type() {
+	case typeTime:
+		return Ydb.Type_TIMESTAMP
+	case typeDuration:
+		return Ydb.Type_INTERVAL
+	case typeRawMessage:
+		return Ydb.Type_JSON
+	}
+
+	switch rv.Kind() { //nolint:exhaustive // other kinds are not supported
+	case reflect.Bool:
+		return Ydb.Type_BOOL
+	case reflect.Int8:
+		return Ydb.Type_INT8
+	case reflect.Int16:
+		return Ydb.Type_INT16
+	case reflect.Int32:
+		return Ydb.Type_INT32
+	case reflect.Int64, reflect.Int:
+		return Ydb.Type_INT64
+	case reflect.Uint8:
+		return Ydb.Type_UINT8
+	case reflect.Uint16:
+		return Ydb.Type_UINT16
+	case reflect.Uint32:
+		return Ydb.Type_UINT32
+	case reflect.Uint64, reflect.Uint:
+		return Ydb.Type_UINT64
+	case reflect.Float32:
+		return Ydb.Type_FLOAT
+	case reflect.Float64:
+		return Ydb.Type_DOUBLE
+	case reflect.String:
+		return Ydb.Type_UTF8
+	case reflect.Slice: // []byte
+		return Ydb.Type_STRING
+	case reflect.Array:
+		if isUUIDArray(rv.Type()) {
+			return Ydb.Type_UUID
+		}
+	}
+
+	return Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED
+}
+
+// primitiveFromGo converts Go value to primitive YDB type.
+//
+//nolint:cyclop,gocognit // flat switch over supported conversions
+func primitiveFromGo(rv reflect.Value, id Ydb.Type_PrimitiveTypeId) (*Ydb.TypedValue, error) {
+	val := &Ydb.Value{}
+
+	switch {
+	case rv.Type() == typeTime:
+		t := rv.Interface().(time.Time) //nolint:forcetypeassert // checked above
+		if t.IsZero() {
+			// zero time is out of range of narrow types, it is also used to derive types of containers
+			t = time.Unix(0, 0).UTC()
+		}
+		tv, err := timeValue(t, id)
+		if err != nil {
+			return nil, err
+		}
+		val = tv
+
+	case rv.Type() == typeDuration && IsDuration(id):
+		tv, err := durationValue(time.Duration(rv.Int()), id)
+		if err != nil {
+			return nil, err
+		}
+		val = tv
+
+	case rv.CanInt():
+		if !setInt(val, id, rv.Int()) {
+			return nil, cannotConvert(rv, id)
+		}
+
+	case rv.CanUint():
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			if id != Ydb.Type_UINT64 {
+				return nil, cannotConvert(rv, id)
+			}
+			val.Value = &Ydb.Value_Uint64Value{Uint64Value: u}
+		} else if !setInt(val, id, int64(u)) {
+			return nil, cannotConvert(rv, id)
+		}
+
+	case rv.CanFloat():
+		switch id { //nolint:exhaustive // other types are not supported
+		case Ydb.Type_FLOAT:
+			val.Value = &Ydb.Value_FloatValue{FloatValue: float32(rv.Float())}
+		case Ydb.Type_DOUBLE:
+			val.Value = &Ydb.Value_DoubleValue{DoubleValue: rv.Float()}
+		default:
+			return nil, cannotConvert(rv, id)
+		}
+
+	case id == Ydb.Type_UUID && (rv.Kind() == reflect.String || isUUIDArray(rv.Type())):
+		return uuidFromGo(rv)
+
+	case rv.Kind() == reflect.Bool && id == Ydb.Type_BOOL:
+		val.Value = &Ydb.Value_BoolValue{BoolValue: rv.Bool()}
+
+	case rv.Kind() == reflect.String || (rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8):
+		var b []byte
+		if rv.Kind() == reflect.String {
+			b = []byte(rv.String())
+		} else {
+			b = rv.Bytes()
+		}
+		switch id { //nolint:exhaustive // other types are not supported
+		case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
+			val.Value = &Ydb.Value_TextValue{TextValue: string(b)}
+		case Ydb.Type_STRING, Ydb.Type_YSON:
+			val.Value = &Ydb.Value_BytesValue{BytesValue: b}
+		default:
+			return nil, cannotConvert(rv, id)
+		}
+
+	default:
+		return nil, cannotConvert(rv, id)
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}},
+		Value: val,
+	}, nil
+}
+
+// setInt sets integer value of provided type checking its range.
+func setInt(val *Ydb.Value, id Ydb.Type_PrimitiveTypeId, v int64) bool {
+	inRange := func(lo, hi int64) bool { return v >= lo && v <= hi }
+
+	switch id { //nolint:exhaustive // other types are not supported
+	case Ydb.Type_INT8:
+		val.Value = &Ydb.Value_Int32Value{Int32Value: int32(v)} //nolint:gosec // range is checked
+		return inRange(math.MinInt8, math.MaxInt8)
+	case Ydb.Type_INT16:
+		val.Value = &Ydb.Value_Int32Value{Int32Value: int32(v)} //nolint:gosec // range is checked
+		return inRange(math.MinInt16, math.MaxInt16)
+	case Ydb.Type_INT32, TypeDate32:
+		val.Value = &Ydb.Value_Int32Value{Int32Value: int32(v)} //nolint:gosec // range is checked
+		return inRange(math.MinInt32, math.MaxInt32)
+	case Ydb.Type_INT64, Ydb.Type_INTERVAL, TypeDatetime64, TypeTimestamp64, TypeInterval64:
+		val.Value = &Ydb.Value_Int64Value{Int64Value: v}
+		return true
+	case Ydb.Type_UINT8:
+		val.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(v)} //nolint:gosec // range is checked
+		return inRange(0, math.MaxUint8)
+	case Ydb.Type_UINT16:
+		val.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(v)} //nolint:gosec // range is checked
+		return inRange(0, math.MaxUint16)
+	case Ydb.Type_UINT32, Ydb.Type_DATE, Ydb.Type_DATETIME:
+		val.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(v)} //nolint:gosec // range is checked
+		return inRange(0, math.MaxUint32)
+	case Ydb.Type_UINT64, Ydb.Type_TIMESTAMP:
+		val.Value = &Ydb.Value_Uint64Value{Uint64Value: uint64(v)} //nolint:gosec // range is checked
+		return v >= 0
+	case Ydb.Type_FLOAT:
+		val.Value = &Ydb.Value_FloatValue{FloatValue: float32(v)}
+		return true
+	case Ydb.Type_DOUBLE:
+		val.Value = &Ydb.Value_DoubleValue{DoubleValue: float64(v)}
+		return true
+	}
+
+	return false
+}
+
+func isUUIDArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8 && t.Len() == len(UUIDValue{})
+}
+
+// uuidFromGo converts UUID bytes or its text representation to Uuid.
+func uuidFromGo(rv reflect.Value) (*Ydb.TypedValue, error) {
+	if rv.Kind() == reflect.String {
+		u, err := ParseUUID(rv.String())
+		if err != nil {
+			return nil, err
+		}
+
+		return UUID(u), nil
+	}
+
+	var u UUIDValue
+	reflect.Copy(reflect.ValueOf(u[:]), rv)
+
+	return UUID(u), nil
+}
+
+func cannotConvert(rv reflect.Value, id Ydb.Type_PrimitiveTypeId) error {
+	if id == Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED {
+		return fmt.Errorf("%w: %s", ErrGoType, rv.Type())
+	}
+
+	return fmt.Errorf("%w: cannot convert %s %v to %s", ErrGoType, rv.Type(), rv.Interface(), primitiveNames[id])
+}
+
+func null() *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_NullType{}},
+		Value: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}},
+	}
+}
+
+func reversePrimitiveNames() map[string]Ydb.Type_PrimitiveTypeId {
+	byName := make(map[string]Ydb.Type_PrimitiveTypeId, len(primitiveNames))
+	for id, name := range primitiveNames {
+		byName[name] = id
+	}
+
+	return byName
+}
@@ -0,0 +1,181 @@
+package types
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestFromGo(t *testing.T) {
+	ts := time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC)
+	str := "test"
+
+	for _, tt := range []struct {
+		arg      any
+		wantType string
+		wantVal  *Ydb.Value
+	}{
+		{arg: true, wantType: "Bool", wantVal: &Ydb.Value{Value: &Ydb.Value_BoolValue{BoolValue: true}}},
+		{arg: int8(-1), wantType: "Int8", wantVal: &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: -1}}},
+		{arg: 42, wantType: "Int64", wantVal: &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: 42}}},
+		{arg: uint16(7), wantType: "Uint16", wantVal: &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: 7}}},
+		{arg: uint(7), wantType: "Uint64", wantVal: &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: 7}}},
+		{arg: 1.5, wantType: "Double", wantVal: &Ydb.Value{Value: &Ydb.Value_DoubleValue{DoubleValue: 1.5}}},
+		{arg: "test", wantType: "Utf8", wantVal: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "test"}}},
+		{arg: []byte("qwe"), wantType: "String", wantVal: &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: []byte("qwe")}}},
+		{
+			arg:      ts,
+			wantType: "Timestamp",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: uint64(ts.UnixMicro())}},
+		},
+		{
+			arg:      2 * time.Second,
+			wantType: "Interval",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: 2000000}},
+		},
+		{arg: nil, wantType: "Null", wantVal: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}},
+		{arg: &str, wantType: "Optional<Utf8>", wantVal: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "test"}}},
+		{arg: (*int32)(nil), wantType: "Optional<Int32>", wantVal: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}},
+		{
+			arg:      sql.NullString{String: "x", Valid: true},
+			wantType: "Utf8",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "x"}},
+		},
+		{arg: sql.NullInt64{}, wantType: "Null", wantVal: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}},
+		{arg: Uint32(5), wantType: "Uint32", wantVal: &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: 5}}},
+	} {
+		tv, err := FromGo(tt.arg)
+		require.NoError(t, err, "%T", tt.arg)
+		assert.Equal(t, tt.wantType, FormatType(tv.Type), "%T", tt.arg)
+		assert.Equal(t, tt.wantVal, tv.Value, "%T", tt.arg)
+	}
+
+	_, err := FromGo(make(chan int))
+	require.ErrorIs(t, err, ErrGoType)
+
+	_, err = FromGo(&[]func(){})
+	require.ErrorIs(t, err, ErrGoType)
+}
+
+type (
+	status string
+
+	point struct{ X, Y int32 }
+
+	base struct {
+		ID uint64
+	}
+
+	user struct {
+		base
+		Created  time.Time  `ydb:"created,type=Datetime"`
+		Birthday *time.Time `ydb:",type=Date"`
+		Tags     []string
+		Attrs    map[string]int32
+		Location point
+		Status   status
+		internal int
+		Skipped  string `ydb:"-"`
+		Avatar   []byte
+	}
+
+	badUser struct {
+		Name string `ydb:",type=Varchar"`
+	}
+
+	marshaled int
+)
+
+func (m *marshaled) MarshalYDB() (*Ydb.TypedValue, error) {
+	return UTF8(fmt.Sprintf("m%d", *m)), nil
+}
+
+func TestFromGo_Reflect(t *testing.T) {
+	created := time.Unix(1726836887, 0)
+	u := user{
+		base:    base{ID: 1},
+		Created: created,
+		Tags:    []string{"a", "b"},
+		Attrs:   map[string]int32{"y": 2, "x": 1},
+		Status:  "active",
+	}
+
+	tv, err := FromGo(u)
+	require.NoError(t, err)
+	assert.Equal(t, "Struct<id:Uint64,created:Datetime,birthday:Optional<Date>,tags:List<Utf8>,"+
+		"attrs:Dict<Utf8,Int32>,location:Struct<x:Int32,y:Int32>,status:Utf8,avatar:String>", FormatType(tv.Type))
+
+	items := tv.Value.Items
+	assert.Equal(t, uint64(1), items[0].GetUint64Value())
+	assert.Equal(t, uint32(1726836887), items[1].GetUint32Value())
+	assert.Equal(t, &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}}, items[2])
+	assert.Len(t, items[3].Items, 2)
+	require.Len(t, items[4].Pairs, 2)
+	assert.Equal(t, "x", items[4].Pairs[0].Key.GetTextValue())
+	assert.Equal(t, "active", items[6].GetTextValue())
+
+	params, err := ParamsFromStruct(&u)
+	require.NoError(t, err)
+	assert.Len(t, params, 8)
+	assert.Equal(t, "Datetime", FormatType(params["$created"].Type))
+	assert.Equal(t, "List<Utf8>", FormatType(params["$tags"].Type))
+
+	_, err = ParamsFromStruct(1)
+	require.ErrorIs(t, err, ErrGoType)
+
+	_, err = FromGo(badUser{})
+	require.ErrorIs(t, err, ErrGoType)
+
+	// empty containers keep their types
+	tv, err = FromGo([][]int16(nil))
+	require.NoError(t, err)
+	assert.Equal(t, "List<List<Int16>>", FormatType(tv.Type))
+
+	tv, err = FromGo([]*string{nil})
+	require.NoError(t, err)
+	assert.Equal(t, "List<Optional<Utf8>>", FormatType(tv.Type))
+
+	// ValueMarshaler with pointer receiver
+	tv, err = FromGo(struct{ M marshaled }{M: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "m3", tv.Value.Items[0].GetTextValue())
+}
+
+func TestFromGo_Interface(t *testing.T) {
+	tv, err := FromGo([]any{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, "List<Int64>", FormatType(tv.Type))
+	require.NoError(t, Check(tv))
+
+	tv, err = FromGo(map[string]any{"a": "x", "b": "y"})
+	require.NoError(t, err)
+	assert.Equal(t, "Dict<Utf8,Utf8>", FormatType(tv.Type))
+	require.NoError(t, Check(tv))
+
+	tv, err = FromGo(map[any]int32{"a": 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Dict<Utf8,Int32>", FormatType(tv.Type))
+	require.NoError(t, Check(tv))
+
+	tv, err = FromGo([]any{[]any{int8(1)}, []any{int8(2)}})
+	require.NoError(t, err)
+	assert.Equal(t, "List<List<Int8>>", FormatType(tv.Type))
+	require.NoError(t, Check(tv))
+
+	for _, arg := range []any{
+		[]any{},
+		[]any{1, "x"},
+		[]any{1, nil},
+		map[string]any{},
+		map[string]any{"a": 1, "b": 1.5},
+		map[any]int{"a": 1, 2: 2},
+	} {
+		_, err = FromGo(arg)
+		require.ErrorIs(t, err, ErrGoType, "%v", arg)
+	}
+}
@@ -0,0 +1,258 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// Default precision and scale of Decimal type, they are used for DecimalValue without precision.
+const (
+	DecimalPrecision = 22
+	DecimalScale     = 9
+)
+
+const (
+	maxDecimalPrecision = 35
+
+	decimalInf = "inf"
+	decimalNaN = "nan"
+)
+
+var (
+	ErrDecimal = errors.New("invalid decimal")
+)
+
+var (
+	// Special values are encoded as values which are out of range of any precision:
+	// 10^35 is inf, 10^35+1 is nan. Larger values are also treated as nan.
+	decInf    = new(big.Int).Exp(big.NewInt(10), big.NewInt(maxDecimalPrecision), nil)
+	decNaN    = new(big.Int).Add(decInf, big.NewInt(1))
+	decNegInf = new(big.Int).Neg(decInf)
+	decNegNaN = new(big.Int).Neg(decNaN)
+
+	int128Mod = new(big.Int).Lsh(big.NewInt(1), 128)
+	uint64Max = new(big.Int).SetUint64(^uint64(0))
+)
+
+// DecimalValue is a number of Decimal(Precision,Scale) type represented by unscaled integer,
+// for example 1.50 of Decimal(22,2) is 150. Nil Unscaled is zero. DecimalValue without precision
+// is of default Decimal(22,9) type.
+//
+// DecimalValue implements ValueMarshaler, so it can be used as query argument.
+// Values of Decimal type can be scanned into DecimalValue.
+type DecimalValue struct {
+	Unscaled  *big.Int
+	Precision uint32
+	Scale     uint32
+}
+
+// Decimal creates Decimal(precision,scale) value from unscaled integer v.
+// Absolute value of v must have not more than precision digits.
+func Decimal(v *big.Int, precision, scale uint32) (*Ydb.TypedValue, error) {
+	if err := checkDecimalType(precision, scale); err != nil {
+		return nil, err
+	}
+	if v == nil {
+		v = new(big.Int)
+	}
+	if !isDecimalSpecial(v) && v.CmpAbs(pow10(precision)) >= 0 {
+		return nil, fmt.Errorf("%w: %s is not valid Decimal(%d,%d)",
+			ErrRange, formatDecimal(v, scale), precision, scale)
+	}
+
+	lo, hi := toInt128(v)
+
+	return &Ydb.TypedValue{
+		Type: &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{
+			Precision: precision,
+			Scale:     scale,
+		}}},
+		Value: &Ydb.Value{Value: &Ydb.Value_Low_128{Low_128: lo}, High_128: hi},
+	}, nil
+}
+
+// DecimalFromString creates Decimal(precision,scale) value from its decimal representation,
+// for example "-12.5". Special values "inf", "-inf" and "nan" are also accepted.
+// Number must have not more than scale fractional digits.
+func DecimalFromString(s string, precision, scale uint32) (*Ydb.TypedValue, error) {
+	d, err := ParseDecimal(s, precision, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	return Decimal(d.Unscaled, precision, scale)
+}
+
+// ParseDecimal parses decimal representation of number the same way as DecimalFromString.
+func ParseDecimal(s string, precision, scale uint32) (DecimalValue, error) {
+	if err := checkDecimalType(precision, scale); err != nil {
+		return DecimalValue{}, err
+	}
+
+	d := DecimalValue{Precision: precision, Scale: scale}
+
+	num := s
+	neg := false
+	if len(num) > 0 && (num[0] == '-' || num[0] == '+') {
+		neg = num[0] == '-'
+		num = num[1:]
+	}
+
+	switch strings.ToLower(num) {
+	case decimalInf:
+		d.Unscaled = new(big.Int).Set(decInf)
+	case decimalNaN:
+		d.Unscaled = new(big.Int).Set(decNaN)
+	default:
+		intPart, fracPart, _ := strings.Cut(num, ".")
+		if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+			return DecimalValue{}, fmt.Errorf("%w: %q is not a number", ErrDecimal, s)
+		}
+		if len(fracPart) > int(scale) {
+			return DecimalValue{}, fmt.Errorf("%w: %q has more than %d fractional digits", ErrDecimal, s, scale)
+		}
+		d.Unscaled, _ = new(big.Int).SetString(intPart+fracPart+strings.Repeat("0", int(scale)-len(fracPart)), 10)
+		if d.Unscaled.CmpAbs(pow10(precision)) >= 0 {
+			return DecimalValue{}, fmt.Errorf("%w: %s is not valid Decimal(%d,%d)", ErrRange, s, precision, scale)
+		}
+	}
+
+	if neg {
+		d.Unscaled.Neg(d.Unscaled)
+	}
+
+	return d, nil
+}
+
+// DecodeDecimal decodes value of Decimal type.
+func DecodeDecimal(typ *Ydb.Type, val *Ydb.Value) (DecimalValue, error) {
+	dt := typ.GetDecimalType()
+	if dt == nil {
+		return DecimalValue{}, fmt.Errorf("%w: %s is not a decimal type", ErrDecode, FormatType(typ))
+	}
+
+	return DecimalValue{
+		Unscaled:  fromInt128(val.GetLow_128(), val.GetHigh_128()),
+		Precision: dt.GetPrecision(),
+		Scale:     dt.GetScale(),
+	}, nil
+}
+
+// MarshalYDB creates Decimal value with precision and scale of d.
+func (d DecimalValue) MarshalYDB() (*Ydb.TypedValue, error) {
+	precision, scale := d.Precision, d.Scale
+	if precision == 0 {
+		precision, scale = DecimalPrecision, DecimalScale
+	}
+
+	return Decimal(d.Unscaled, precision, scale)
+}
+
+// String returns exact decimal representation of d with Scale fractional digits,
+// special values are represented as inf, -inf and nan.
+func (d DecimalValue) String() string {
+	if d.Unscaled == nil {
+		return formatDecimal(new(big.Int), d.Scale)
+	}
+
+	return formatDecimal(d.Unscaled, d.Scale)
+}
+
+// IsInf reports whether d is positive or negative infinity.
+func (d DecimalValue) IsInf() bool {
+	return d.Unscaled != nil && d.Unscaled.CmpAbs(decInf) == 0
+}
+
+// IsNaN reports whether d is not a number.
+func (d DecimalValue) IsNaN() bool {
+	return d.Unscaled != nil && d.Unscaled.CmpAbs(decNaN) >= 0
+}
+
+// Rat returns d as rational number. Special values cannot be represented as big.Rat.
+func (d DecimalValue) Rat() (*big.Rat, error) {
+	if d.IsInf() || d.IsNaN() {
+		return nil, fmt.Errorf("%w: %s cannot be represented as big.Rat", ErrDecimal, d)
+	}
+	if d.Unscaled == nil {
+		return new(big.Rat), nil
+	}
+
+	return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale)), nil
+}
+
+func checkDecimalType(precision, scale uint32) error {
+	if precision == 0 || precision > maxDecimalPrecision || scale > precision {
+		return fmt.Errorf("%w: Decimal(%d,%d) is not supported", ErrDecimal, precision, scale)
+	}
+
+	return nil
+}
+
+func isDecimalSpecial(v *big.Int) bool {
+	return v.CmpAbs(decInf) == 0 || v.CmpAbs(decNaN) == 0
+}
+
+func formatDecimal(v *big.Int, scale uint32) string {
+	switch {
+	case v.Cmp(decNaN) >= 0, v.Cmp(decNegNaN) <= 0:
+		return decimalNaN
+	case v.Cmp(decInf) == 0:
+		return decimalInf
+	case v.Cmp(decNegInf) == 0:
+		return "-" + decimalInf
+	}
+
+	digits := new(big.Int).Abs(v).String()
+	if scale > 0 {
+		if pad := int(scale) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// toInt128 returns low and high halves of 128-bit two's complement representation of v.
+func toInt128(v *big.Int) (uint64, uint64) {
+	u := new(big.Int).Set(v)
+	if u.Sign() < 0 {
+		u.Add(u, int128Mod)
+	}
+	lo := new(big.Int).And(u, uint64Max).Uint64()
+	hi := u.Rsh(u, 64).Uint64()
+
+	return lo, hi
+}
+
+func fromInt128(lo, hi uint64) *big.Int {
+	v := new(big.Int).SetUint64(hi)
+	v.Lsh(v, 64)
+	v.Or(v, new(big.Int).SetUint64(lo))
+	if hi>>63 == 1 {
+		v.Sub(v, int128Mod)
+	}
+
+	return v
+}
+
+func pow10(n uint32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
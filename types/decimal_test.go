@@ -0,0 +1,95 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestDecimal(t *testing.T) {
+	for _, tt := range []struct {
+		in      string
+		want    string
+		wantLow uint64
+		wantHi  uint64
+	}{
+		{in: "1.5", want: "1.50", wantLow: 150},
+		{in: "-0.01", want: "-0.01", wantLow: ^uint64(0), wantHi: ^uint64(0)},
+		{in: "0", want: "0.00"},
+		{in: ".5", want: "0.50", wantLow: 50},
+		{in: "184467440737095516.16", want: "184467440737095516.16", wantLow: 0, wantHi: 1},
+		{in: "inf", want: "inf"},
+		{in: "-inf", want: "-inf"},
+		{in: "nan", want: "nan"},
+	} {
+		tv, err := DecimalFromString(tt.in, 22, 2)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, "Decimal(22,2)", FormatType(tv.Type))
+		if tt.wantLow != 0 || tt.wantHi != 0 {
+			assert.Equal(t, tt.wantLow, tv.Value.GetLow_128(), tt.in)
+			assert.Equal(t, tt.wantHi, tv.Value.GetHigh_128(), tt.in)
+		}
+
+		dec, err := DecodeDecimal(tv.Type, tv.Value)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, dec.String(), tt.in)
+	}
+}
+
+func TestDecimal_Errors(t *testing.T) {
+	_, err := DecimalFromString("1.234", 22, 2)
+	require.ErrorIs(t, err, ErrDecimal)
+
+	_, err = DecimalFromString("1e5", 22, 2)
+	require.ErrorIs(t, err, ErrDecimal)
+
+	_, err = DecimalFromString("-", 22, 2)
+	require.ErrorIs(t, err, ErrDecimal)
+
+	_, err = DecimalFromString("1000", 5, 2)
+	require.ErrorIs(t, err, ErrRange)
+
+	_, err = Decimal(big.NewInt(100000), 5, 2)
+	require.ErrorIs(t, err, ErrRange)
+
+	_, err = Decimal(big.NewInt(1), 36, 2)
+	require.ErrorIs(t, err, ErrDecimal)
+
+	_, err = Decimal(big.NewInt(1), 2, 3)
+	require.ErrorIs(t, err, ErrDecimal)
+
+	_, err = DecodeDecimal(Uint64(0).Type, &Ydb.Value{})
+	require.ErrorIs(t, err, ErrDecode)
+}
+
+func TestDecimalValue(t *testing.T) {
+	d, err := ParseDecimal("-12.345", 10, 3)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(-12345), d.Unscaled)
+	assert.False(t, d.IsInf())
+	assert.False(t, d.IsNaN())
+
+	r, err := d.Rat()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(-12345, 1000), r)
+
+	tv, err := FromGo(struct{ Amount DecimalValue }{Amount: d})
+	require.NoError(t, err)
+	assert.Equal(t, "Struct<amount:Decimal(10,3)>", FormatType(tv.Type))
+
+	// default precision
+	tv, err = FromGo([]*DecimalValue{nil})
+	require.NoError(t, err)
+	assert.Equal(t, "List<Optional<Decimal(22,9)>>", FormatType(tv.Type))
+
+	inf, err := ParseDecimal("-INF", 10, 3)
+	require.NoError(t, err)
+	assert.True(t, inf.IsInf())
+	_, err = inf.Rat()
+	require.ErrorIs(t, err, ErrDecimal)
+
+	assert.Equal(t, "0.000", DecimalValue{Scale: 3}.String())
+}
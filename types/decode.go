@@ -0,0 +1,341 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+type (
+	// VariantValue is a decoded value of Variant type.
+	VariantValue struct {
+		Value any
+		// Name is a name of alternative of variant over struct, it is empty for variant over tuple.
+		Name  string
+		Index uint32
+	}
+
+	// PathError is an error of decoding nested value. Path is built from
+	// list, tuple and dict indexes and struct member names, for example [3].tags[2].
+	PathError struct {
+		Err  error
+		Path string
+	}
+)
+
+func (e *PathError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// WithPath prepends path segment to err. If err is not a PathError, it is wrapped into PathError.
+func WithPath(err error, segment string) error {
+	var pe *PathError
+	if errors.As(err, &pe) {
+		return &PathError{Path: segment + pe.Path, Err: pe.Err}
+	}
+
+	return &PathError{Path: segment, Err: err}
+}
+
+// ToGo decodes value of provided type into native Go value:
+//   - Bool to bool, IntN and UintN to intN and uintN, Float and Double to float32 and float64
+//   - Utf8, Json, JsonDocument and DyNumber to string, String and Yson to []byte
+//   - date and time types to time.Time, Interval and Interval64 to time.Duration
+//   - Decimal to DecimalValue, Uuid to UUIDValue
+//   - Optional to value of its item or nil for NULL, Void and Null to nil
+//   - List and Tuple to []any, Struct to map[string]any, Dict to map[any]any
+//     with String keys converted to string, Variant to VariantValue
+//   - Tagged to value of its type, Pg types to their text or binary representation
+//
+// Value kind is checked against type, errors of nested values are reported as PathError.
+//
+//nolint:cyclop,gocognit // flat switch over type kinds
+func ToGo(typ *Ydb.Type, val *Ydb.Value) (any, error) {
+	if val == nil {
+		return nil, fmt.Errorf("%w: value is not set, expected %s", ErrDecode, FormatType(typ))
+	}
+
+	switch t := typ.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		if err := checkPrimitive(typ, t.TypeId, val); err != nil {
+			return nil, decodeMismatch(typ, val)
+		}
+
+		return primitiveToGo(typ, t.TypeId, val)
+	case *Ydb.Type_DecimalType:
+		if _, ok := val.GetValue().(*Ydb.Value_Low_128); !ok {
+			return nil, decodeMismatch(typ, val)
+		}
+
+		return DecodeDecimal(typ, val)
+	case *Ydb.Type_OptionalType:
+		item := t.OptionalType.GetItem()
+		switch v := val.GetValue().(type) {
+		case *Ydb.Value_NullFlagValue:
+			return nil, nil
+		case *Ydb.Value_NestedValue:
+			if _, nested := item.GetType().(*Ydb.Type_OptionalType); nested {
+				return ToGo(item, v.NestedValue)
+			}
+		}
+
+		return ToGo(item, val)
+	case *Ydb.Type_ListType:
+		list := make([]any, 0, len(val.GetItems()))
+		for i, itemVal := range val.GetItems() {
+			item, err := ToGo(t.ListType.GetItem(), itemVal)
+			if err != nil {
+				return nil, WithPath(err, indexSegment(i))
+			}
+			list = append(list, item)
+		}
+
+		return list, nil
+	case *Ydb.Type_TupleType:
+		elements := t.TupleType.GetElements()
+		if len(val.GetItems()) != len(elements) {
+			return nil, fmt.Errorf("%w: %d items, expected %s", ErrDecode, len(val.GetItems()), FormatType(typ))
+		}
+		tuple := make([]any, 0, len(elements))
+		for i, itemVal := range val.GetItems() {
+			item, err := ToGo(elements[i], itemVal)
+			if err != nil {
+				return nil, WithPath(err, indexSegment(i))
+			}
+			tuple = append(tuple, item)
+		}
+
+		return tuple, nil
+	case *Ydb.Type_StructType:
+		members := t.StructType.GetMembers()
+		if len(val.GetItems()) != len(members) {
+			return nil, fmt.Errorf("%w: %d members, expected %s", ErrDecode, len(val.GetItems()), FormatType(typ))
+		}
+		st := make(map[string]any, len(members))
+		for i, itemVal := range val.GetItems() {
+			item, err := ToGo(members[i].GetType(), itemVal)
+			if err != nil {
+				return nil, WithPath(err, memberSegment(members[i].GetName()))
+			}
+			st[members[i].GetName()] = item
+		}
+
+		return st, nil
+	case *Ydb.Type_DictType:
+		return dictToGo(t.DictType, val)
+	case *Ydb.Type_VariantType:
+		return variantToGo(typ, t.VariantType, val)
+	case *Ydb.Type_TaggedType:
+		return ToGo(t.TaggedType.GetType(), val)
+	case *Ydb.Type_VoidType, *Ydb.Type_NullType:
+		return nil, nil
+	case *Ydb.Type_EmptyListType:
+		return []any{}, nil
+	case *Ydb.Type_EmptyDictType:
+		return map[any]any{}, nil
+	case *Ydb.Type_PgType:
+		switch v := val.GetValue().(type) {
+		case *Ydb.Value_TextValue:
+			return v.TextValue, nil
+		case *Ydb.Value_BytesValue:
+			return v.BytesValue, nil
+		case *Ydb.Value_NullFlagValue:
+			return nil, nil
+		}
+
+		return nil, decodeMismatch(typ, val)
+	}
+
+	return nil, fmt.Errorf("%w: %s is not supported", ErrDecode, FormatType(typ))
+}
+
+// As decodes value of provided type into T. Value is decoded with ToGo and converted to T:
+//   - integers are converted to other integer types if they fit
+//   - float32 is converted to float64
+//   - T can be a pointer to decoded type, NULL is decoded as nil pointer
+//   - NULL can be decoded into T which has nil value (pointers, slices, maps and interfaces)
+func As[T any](typ *Ydb.Type, val *Ydb.Value) (T, error) {
+	var zero T
+
+	v, err := ToGo(typ, val)
+	if err != nil {
+		return zero, err
+	}
+	if tv, ok := v.(T); ok {
+		return tv, nil
+	}
+
+	dst := reflect.ValueOf(&zero).Elem()
+	if err = convertTo(dst, v); err != nil {
+		return zero, fmt.Errorf("%w: cannot convert %s to %s", err, FormatType(typ), dst.Type())
+	}
+
+	return zero, nil
+}
+
+func convertTo(dst reflect.Value, v any) error {
+	if v == nil {
+		switch dst.Kind() { //nolint:exhaustive // other kinds are not nullable
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			return nil
+		}
+
+		return fmt.Errorf("%w: NULL", ErrDecode)
+	}
+
+	src := reflect.ValueOf(v)
+	switch {
+	case dst.Kind() == reflect.Pointer && src.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(src)
+		dst.Set(ptr)
+	case src.CanInt() && dst.CanInt() && !dst.OverflowInt(src.Int()) && dst.Type() != typeDuration:
+		dst.SetInt(src.Int())
+	case src.CanInt() && dst.CanUint() && src.Int() >= 0 && !dst.OverflowUint(uint64(src.Int())):
+		dst.SetUint(uint64(src.Int()))
+	case src.CanUint() && dst.CanUint() && !dst.OverflowUint(src.Uint()):
+		dst.SetUint(src.Uint())
+	case src.CanUint() && dst.CanInt() && src.Uint() <= 1<<63-1 && !dst.OverflowInt(int64(src.Uint())) &&
+		dst.Type() != typeDuration:
+		dst.SetInt(int64(src.Uint()))
+	case src.Kind() == reflect.Float32 && dst.Kind() == reflect.Float64:
+		dst.SetFloat(src.Float())
+	default:
+		return ErrDecode
+	}
+
+	return nil
+}
+
+//nolint:cyclop // flat switch over primitive types
+func primitiveToGo(typ *Ydb.Type, id Ydb.Type_PrimitiveTypeId, val *Ydb.Value) (any, error) {
+	switch id { //nolint:exhaustive // other types are handled below
+	case Ydb.Type_BOOL:
+		return val.GetBoolValue(), nil
+	case Ydb.Type_INT8:
+		return int8(val.GetInt32Value()), nil //nolint:gosec // range is checked
+	case Ydb.Type_INT16:
+		return int16(val.GetInt32Value()), nil //nolint:gosec // range is checked
+	case Ydb.Type_INT32:
+		return val.GetInt32Value(), nil
+	case Ydb.Type_INT64:
+		return val.GetInt64Value(), nil
+	case Ydb.Type_UINT8:
+		return uint8(val.GetUint32Value()), nil //nolint:gosec // range is checked
+	case Ydb.Type_UINT16:
+		return uint16(val.GetUint32Value()), nil //nolint:gosec // range is checked
+	case Ydb.Type_UINT32:
+		return val.GetUint32Value(), nil
+	case Ydb.Type_UINT64:
+		return val.GetUint64Value(), nil
+	case Ydb.Type_FLOAT:
+		return val.GetFloatValue(), nil
+	case Ydb.Type_DOUBLE:
+		return val.GetDoubleValue(), nil
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
+		return val.GetTextValue(), nil
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		return append([]byte(nil), val.GetBytesValue()...), nil
+	case Ydb.Type_UUID:
+		return DecodeUUID(typ, val)
+	}
+
+	switch {
+	case IsTime(id):
+		return DecodeTime(typ, val)
+	case IsDuration(id):
+		return DecodeDuration(typ, val)
+	}
+
+	return nil, fmt.Errorf("%w: %s is not supported", ErrDecode, FormatType(typ))
+}
+
+func dictToGo(dt *Ydb.DictType, val *Ydb.Value) (any, error) {
+	dict := make(map[any]any, len(val.GetPairs()))
+	for i, pair := range val.GetPairs() {
+		key, err := ToGo(dt.GetKey(), pair.GetKey())
+		if err != nil {
+			return nil, WithPath(err, indexSegment(i)+".key")
+		}
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, WithPath(fmt.Errorf("%w: %s key is not comparable", ErrDecode, FormatType(dt.GetKey())),
+				indexSegment(i)+".key")
+		}
+		payload, err := ToGo(dt.GetPayload(), pair.GetPayload())
+		if err != nil {
+			return nil, WithPath(err, keySegment(key))
+		}
+		dict[key] = payload
+	}
+
+	return dict, nil
+}
+
+func variantToGo(typ *Ydb.Type, vt *Ydb.VariantType, val *Ydb.Value) (any, error) {
+	nested, ok := val.GetValue().(*Ydb.Value_NestedValue)
+	if !ok {
+		return nil, decodeMismatch(typ, val)
+	}
+
+	var (
+		v           = VariantValue{Index: val.GetVariantIndex()}
+		alternative *Ydb.Type
+		segment     = indexSegment(int(v.Index))
+	)
+	switch items := vt.GetType().(type) {
+	case *Ydb.VariantType_TupleItems:
+		if elements := items.TupleItems.GetElements(); int(v.Index) < len(elements) {
+			alternative = elements[v.Index]
+		}
+	case *Ydb.VariantType_StructItems:
+		if members := items.StructItems.GetMembers(); int(v.Index) < len(members) {
+			alternative, v.Name = members[v.Index].GetType(), members[v.Index].GetName()
+			segment = memberSegment(v.Name)
+		}
+	}
+	if alternative == nil {
+		return nil, fmt.Errorf("%w: %w: index %d, expected %s", ErrDecode, ErrVariant, v.Index, FormatType(typ))
+	}
+
+	item, err := ToGo(alternative, nested.NestedValue)
+	if err != nil {
+		return nil, WithPath(err, segment)
+	}
+	v.Value = item
+
+	return v, nil
+}
+
+func decodeMismatch(typ *Ydb.Type, val *Ydb.Value) error {
+	return fmt.Errorf("%w: expected %s, got %s", ErrDecode, FormatType(typ), valueKind(val))
+}
+
+func indexSegment(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+func memberSegment(name string) string {
+	if isIdent(name) {
+		return "." + name
+	}
+
+	return "." + strconv.Quote(name)
+}
+
+func keySegment(key any) string {
+	if s, ok := key.(string); ok {
+		return "[" + strconv.Quote(s) + "]"
+	}
+
+	return "[" + fmt.Sprint(key) + "]"
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestToGo(t *testing.T) {
+	ts := time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC)
+	tsVal, err := Timestamp(ts)
+	require.NoError(t, err)
+	dec, err := DecimalFromString("1.5", 22, 2)
+	require.NoError(t, err)
+	tags, err := List(UTF8("a"), UTF8("b"))
+	require.NoError(t, err)
+	dict, err := Dict(Pair(Bytes([]byte("k")), NullOf(Int32(0).Type)))
+	require.NoError(t, err)
+	variant, err := VariantStruct("err", UTF8("failed"),
+		&Ydb.StructMember{Name: "ok", Type: Uint64(0).Type}, &Ydb.StructMember{Name: "err", Type: UTF8("").Type})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		tv   *Ydb.TypedValue
+		want any
+	}{
+		{tv: Bool(true), want: true},
+		{tv: Int32(-1), want: int32(-1)},
+		{tv: Uint64(1), want: uint64(1)},
+		{tv: Float(1.5), want: float32(1.5)},
+		{tv: Bytes([]byte("a")), want: []byte("a")},
+		{tv: JSON(`{}`), want: `{}`},
+		{tv: tsVal, want: ts},
+		{tv: Interval64(time.Second), want: time.Second},
+		{tv: UUID([16]byte{1}), want: UUIDValue{1}},
+		{tv: Optional(Optional(UTF8("x"))), want: "x"},
+		{tv: NullOf(UTF8("").Type), want: nil},
+		{tv: Tuple(Bool(false), tags), want: []any{false, []any{"a", "b"}}},
+		{tv: Struct(Field("id", Uint64(1)), Field("tags", tags)), want: map[string]any{"id": uint64(1), "tags": []any{"a", "b"}}},
+		{tv: dict, want: map[any]any{"k": nil}},
+		{tv: variant, want: VariantValue{Name: "err", Index: 1, Value: "failed"}},
+	} {
+		got, errG := ToGo(tt.tv.Type, tt.tv.Value)
+		require.NoError(t, errG, FormatType(tt.tv.Type))
+		assert.Equal(t, tt.want, got, FormatType(tt.tv.Type))
+	}
+
+	got, err := ToGo(dec.Type, dec.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "1.50", got.(DecimalValue).String())
+}
+
+func TestToGo_Path(t *testing.T) {
+	tags, err := List(UTF8("a"), UTF8("b"), UTF8("c"))
+	require.NoError(t, err)
+	rows, err := List(Struct(Field("tags", tags)))
+	require.NoError(t, err)
+	rows.Value.Items[0].Items[0].Items[2] = Uint64(1).Value
+
+	_, err = ToGo(rows.Type, rows.Value)
+	require.ErrorIs(t, err, ErrDecode)
+	assert.Equal(t, "[0].tags[2]: cannot decode value: expected Utf8, got Uint64Value", err.Error())
+
+	var pe *PathError
+	require.ErrorAs(t, WithPath(err, `row[3]`), &pe)
+	assert.Equal(t, "row[3][0].tags[2]", pe.Path)
+
+	dict, err := Dict(Pair(UTF8("a b"), Struct(Field("user name", Int32(1)))))
+	require.NoError(t, err)
+	dict.Value.Pairs[0].Payload.Items[0] = Bool(true).Value
+	_, err = ToGo(dict.Type, dict.Value)
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, `["a b"]."user name"`, pe.Path)
+}
+
+func TestAs(t *testing.T) {
+	v, err := As[int64](Int32(-5).Type, Int32(-5).Value)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5), v)
+
+	u8, err := As[uint8](Uint64(200).Type, Uint64(200).Value)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(200), u8)
+
+	_, err = As[uint8](Uint64(300).Type, Uint64(300).Value)
+	require.ErrorIs(t, err, ErrDecode)
+
+	s, err := As[string](UTF8("x").Type, UTF8("x").Value)
+	require.NoError(t, err)
+	assert.Equal(t, "x", s)
+
+	opt := NullOf(UTF8("").Type)
+	ptr, err := As[*string](opt.Type, opt.Value)
+	require.NoError(t, err)
+	assert.Nil(t, ptr)
+
+	opt = Optional(UTF8("y"))
+	ptr, err = As[*string](opt.Type, opt.Value)
+	require.NoError(t, err)
+	require.NotNil(t, ptr)
+	assert.Equal(t, "y", *ptr)
+
+	_, err = As[string](NullOf(UTF8("").Type).Type, NullOf(UTF8("").Type).Value)
+	require.ErrorIs(t, err, ErrDecode)
+
+	_, err = As[time.Duration](Int64(1).Type, Int64(1).Value)
+	require.ErrorIs(t, err, ErrDecode)
+
+	f, err := As[float64](Float(0.5).Type, Float(0.5).Value)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, f, 0)
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	// dyNumberRe matches number in decimal or scientific notation.
+	dyNumberRe = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)([eE][+-]?\d+)?$`)
+)
+
+// JSON creates Json value from JSON text. Text is not validated.
+func JSON(v string) *Ydb.TypedValue {
+	return textValue(Ydb.Type_JSON, v)
+}
+
+// JSONOf creates Json value from v marshaled with encoding/json.
+// json.RawMessage is passed as is.
+func JSONOf(v any) (*Ydb.TypedValue, error) {
+	return jsonOf(Ydb.Type_JSON, v)
+}
+
+// JSONDocument creates JsonDocument value from JSON text. Text is not validated.
+func JSONDocument(v string) *Ydb.TypedValue {
+	return textValue(Ydb.Type_JSON_DOCUMENT, v)
+}
+
+// JSONDocumentOf creates JsonDocument value from v marshaled with encoding/json.
+// json.RawMessage is passed as is.
+func JSONDocumentOf(v any) (*Ydb.TypedValue, error) {
+	return jsonOf(Ydb.Type_JSON_DOCUMENT, v)
+}
+
+// DecodeJSON unmarshals value of Json or JsonDocument type into dst with encoding/json.
+func DecodeJSON(typ *Ydb.Type, val *Ydb.Value, dst any) error {
+	if id := typ.GetTypeId(); id != Ydb.Type_JSON && id != Ydb.Type_JSON_DOCUMENT {
+		return fmt.Errorf("%w: %s is not a JSON type", ErrDecode, FormatType(typ))
+	}
+	if err := json.Unmarshal([]byte(val.GetTextValue()), dst); err != nil {
+		return errors.Join(ErrDecode, err)
+	}
+
+	return nil
+}
+
+// Yson creates Yson value from its binary or text representation. Value is not validated.
+func Yson(v []byte) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_YSON}},
+		Value: &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: v}},
+	}
+}
+
+// DyNumber creates DyNumber value from number in decimal or scientific notation, like "1.5e-10".
+func DyNumber(v string) (*Ydb.TypedValue, error) {
+	if !dyNumberRe.MatchString(v) {
+		return nil, fmt.Errorf("%w: %q is not DyNumber", ErrFormat, v)
+	}
+
+	return textValue(Ydb.Type_DYNUMBER, v), nil
+}
+
+// DecodeText decodes value of Utf8, Json, JsonDocument or DyNumber type.
+func DecodeText(typ *Ydb.Type, val *Ydb.Value) (string, error) {
+	switch typ.GetTypeId() { //nolint:exhaustive // other types are not text
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER:
+		return val.GetTextValue(), nil
+	}
+
+	return "", fmt.Errorf("%w: %s is not a text type", ErrDecode, FormatType(typ))
+}
+
+// DecodeBytes decodes value of String or Yson type.
+func DecodeBytes(typ *Ydb.Type, val *Ydb.Value) ([]byte, error) {
+	switch typ.GetTypeId() { //nolint:exhaustive // other types are not binary
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		return val.GetBytesValue(), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s is not a binary type", ErrDecode, FormatType(typ))
+}
+
+func jsonOf(id Ydb.Type_PrimitiveTypeId, v any) (*Ydb.TypedValue, error) {
+	if raw, ok := v.(json.RawMessage); ok {
+		return textValue(id, string(raw)), nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Join(ErrGoType, err)
+	}
+
+	return textValue(id, string(b)), nil
+}
+
+func textValue(id Ydb.Type_PrimitiveTypeId, v string) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}},
+		Value: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: v}},
+	}
+}
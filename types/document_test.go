@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestJSON(t *testing.T) {
+	type doc struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	tv, err := JSONDocumentOf(doc{Name: "a", Tags: []string{"x"}})
+	require.NoError(t, err)
+	assert.Equal(t, "JsonDocument", FormatType(tv.Type))
+	assert.JSONEq(t, `{"name":"a","tags":["x"]}`, tv.Value.GetTextValue())
+
+	var decoded doc
+	require.NoError(t, DecodeJSON(tv.Type, tv.Value, &decoded))
+	assert.Equal(t, doc{Name: "a", Tags: []string{"x"}}, decoded)
+
+	tv, err = JSONOf(json.RawMessage(`[1,2]`))
+	require.NoError(t, err)
+	assert.Equal(t, "Json", FormatType(tv.Type))
+	assert.Equal(t, `[1,2]`, tv.Value.GetTextValue())
+
+	tv, err = FromGo(json.RawMessage(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, JSON(`{}`), tv)
+
+	_, err = JSONOf(make(chan int))
+	require.ErrorIs(t, err, ErrGoType)
+
+	require.ErrorIs(t, DecodeJSON(tv.Type, &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "{"}}, &decoded), ErrDecode)
+	require.ErrorIs(t, DecodeJSON(UTF8("").Type, tv.Value, &decoded), ErrDecode)
+}
+
+func TestTextAndBytes(t *testing.T) {
+	tv := Text("abc")
+	assert.Equal(t, "String", FormatType(tv.Type))
+	assert.Equal(t, []byte("abc"), tv.Value.GetBytesValue())
+	assert.Equal(t, Bytes([]byte("abc")), tv)
+
+	b, err := DecodeBytes(Yson([]byte("{a=1}")).Type, Yson([]byte("{a=1}")).Value)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("{a=1}"), b)
+
+	tv, err = DyNumber("-1.5e-130")
+	require.NoError(t, err)
+	s, err := DecodeText(tv.Type, tv.Value)
+	require.NoError(t, err)
+	assert.Equal(t, "-1.5e-130", s)
+
+	for _, v := range []string{"0", "+12", "1.", ".5", "1E10", "-0.001e+5"} {
+		_, err = DyNumber(v)
+		require.NoError(t, err, v)
+	}
+	for _, v := range []string{"", "1.5x", "Inf", "-inf", "NaN", "0x1p4", "1_000", ".", "e5", "1e", "1.5.2", " 1"} {
+		_, err = DyNumber(v)
+		require.ErrorIs(t, err, ErrFormat, v)
+	}
+
+	_, err = DecodeText(tv.Type, nil)
+	require.NoError(t, err)
+	_, err = DecodeBytes(tv.Type, tv.Value)
+	require.ErrorIs(t, err, ErrDecode)
+}
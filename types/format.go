@@ -0,0 +1,187 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const unknownType = "Unknown"
+
+// primitiveNames maps primitive type ids to YQL type names.
+var primitiveNames = map[Ydb.Type_PrimitiveTypeId]string{
+	Ydb.Type_BOOL:          "Bool",
+	Ydb.Type_INT8:          "Int8",
+	Ydb.Type_UINT8:         "Uint8",
+	Ydb.Type_INT16:         "Int16",
+	Ydb.Type_UINT16:        "Uint16",
+	Ydb.Type_INT32:         "Int32",
+	Ydb.Type_UINT32:        "Uint32",
+	Ydb.Type_INT64:         "Int64",
+	Ydb.Type_UINT64:        "Uint64",
+	Ydb.Type_FLOAT:         "Float",
+	Ydb.Type_DOUBLE:        "Double",
+	Ydb.Type_DATE:          "Date",
+	Ydb.Type_DATETIME:      "Datetime",
+	Ydb.Type_TIMESTAMP:     "Timestamp",
+	Ydb.Type_INTERVAL:      "Interval",
+	Ydb.Type_TZ_DATE:       "TzDate",
+	Ydb.Type_TZ_DATETIME:   "TzDatetime",
+	Ydb.Type_TZ_TIMESTAMP:  "TzTimestamp",
+	TypeDate32:             "Date32",
+	TypeDatetime64:         "Datetime64",
+	TypeTimestamp64:        "Timestamp64",
+	TypeInterval64:         "Interval64",
+	Ydb.Type_STRING:        "String",
+	Ydb.Type_UTF8:          "Utf8",
+	Ydb.Type_YSON:          "Yson",
+	Ydb.Type_JSON:          "Json",
+	Ydb.Type_UUID:          "Uuid",
+	Ydb.Type_JSON_DOCUMENT: "JsonDocument",
+	Ydb.Type_DYNUMBER:      "DyNumber",
+}
+
+// pgNames maps oids of common PostgreSQL types to YQL names.
+var pgNames = map[uint32]string{
+	16:   "PgBool",
+	17:   "PgBytea",
+	20:   "PgInt8",
+	21:   "PgInt2",
+	23:   "PgInt4",
+	25:   "PgText",
+	114:  "PgJson",
+	700:  "PgFloat4",
+	701:  "PgFloat8",
+	1043: "PgVarchar",
+	1082: "PgDate",
+	1114: "PgTimestamp",
+	1186: "PgInterval",
+	1700: "PgNumeric",
+	2950: "PgUuid",
+	3802: "PgJsonb",
+}
+
+// FormatType renders type in YQL syntax, for example Optional<List<Struct<id:Uint64,name:Utf8>>>.
+// Types which cannot be represented in YQL are rendered as Unknown.
+func FormatType(t *Ydb.Type) string {
+	var b strings.Builder
+	formatType(&b, t)
+
+	return b.String()
+}
+
+func formatType(b *strings.Builder, t *Ydb.Type) {
+	switch tt := t.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		name, ok := primitiveNames[tt.TypeId]
+		if !ok {
+			name = unknownType
+		}
+		b.WriteString(name)
+	case *Ydb.Type_DecimalType:
+		b.WriteString("Decimal(")
+		b.WriteString(strconv.FormatUint(uint64(tt.DecimalType.GetPrecision()), 10))
+		b.WriteByte(',')
+		b.WriteString(strconv.FormatUint(uint64(tt.DecimalType.GetScale()), 10))
+		b.WriteByte(')')
+	case *Ydb.Type_OptionalType:
+		formatContainer(b, "Optional", tt.OptionalType.GetItem())
+	case *Ydb.Type_ListType:
+		formatContainer(b, "List", tt.ListType.GetItem())
+	case *Ydb.Type_TupleType:
+		formatContainer(b, "Tuple", tt.TupleType.GetElements()...)
+	case *Ydb.Type_StructType:
+		b.WriteString("Struct")
+		formatMembers(b, tt.StructType.GetMembers())
+	case *Ydb.Type_DictType:
+		formatContainer(b, "Dict", tt.DictType.GetKey(), tt.DictType.GetPayload())
+	case *Ydb.Type_VariantType:
+		switch items := tt.VariantType.GetType().(type) {
+		case *Ydb.VariantType_TupleItems:
+			formatContainer(b, "Variant", items.TupleItems.GetElements()...)
+		case *Ydb.VariantType_StructItems:
+			b.WriteString("Variant")
+			formatMembers(b, items.StructItems.GetMembers())
+		default:
+			b.WriteString(unknownType)
+		}
+	case *Ydb.Type_TaggedType:
+		b.WriteString("Tagged<")
+		formatType(b, tt.TaggedType.GetType())
+		b.WriteByte(',')
+		b.WriteString(strconv.Quote(tt.TaggedType.GetTag()))
+		b.WriteByte('>')
+	case *Ydb.Type_VoidType:
+		b.WriteString("Void")
+	case *Ydb.Type_NullType:
+		b.WriteString("Null")
+	case *Ydb.Type_EmptyListType:
+		b.WriteString("EmptyList")
+	case *Ydb.Type_EmptyDictType:
+		b.WriteString("EmptyDict")
+	case *Ydb.Type_PgType:
+		name, ok := pgNames[tt.PgType.GetOid()]
+		if !ok {
+			name = unknownType
+		}
+		b.WriteString(name)
+	default:
+		b.WriteString(unknownType)
+	}
+}
+
+func formatContainer(b *strings.Builder, name string, items ...*Ydb.Type) {
+	b.WriteString(name)
+	b.WriteByte('<')
+	for i, item := range items {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		formatType(b, item)
+	}
+	b.WriteByte('>')
+}
+
+func formatMembers(b *strings.Builder, members []*Ydb.StructMember) {
+	b.WriteByte('<')
+	for i, m := range members {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		formatName(b, m.GetName())
+		b.WriteByte(':')
+		formatType(b, m.GetType())
+	}
+	b.WriteByte('>')
+}
+
+// nameEscaper escapes quoted member name the way it is unescaped by ParseType.
+var nameEscaper = strings.NewReplacer("\\", "\\\\", "`", "\\`")
+
+// formatName writes struct member name, quoting it if it is not a plain identifier.
+func formatName(b *strings.Builder, name string) {
+	if isIdent(name) {
+		b.WriteString(name)
+		return
+	}
+	b.WriteByte('`')
+	b.WriteString(nameEscaper.Replace(name))
+	b.WriteByte('`')
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestFormatType(t *testing.T) {
+	uint64T := &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UINT64}}
+	utf8T := &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UTF8}}
+
+	tests := []struct {
+		typ  *Ydb.Type
+		want string
+	}{
+		{typ: uint64T, want: "Uint64"},
+		{
+			typ:  &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{Precision: 22, Scale: 9}}},
+			want: "Decimal(22,9)",
+		},
+		{
+			typ: &Ydb.Type{Type: &Ydb.Type_OptionalType{OptionalType: &Ydb.OptionalType{
+				Item: &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{
+					Item: &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{
+						Members: []*Ydb.StructMember{
+							{Name: "id", Type: uint64T},
+							{Name: "user name", Type: utf8T},
+						},
+					}}},
+				}}},
+			}}},
+			want: "Optional<List<Struct<id:Uint64,`user name`:Utf8>>>",
+		},
+		{
+			typ:  &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{Key: utf8T, Payload: uint64T}}},
+			want: "Dict<Utf8,Uint64>",
+		},
+		{
+			typ:  &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: []*Ydb.Type{utf8T, uint64T}}}},
+			want: "Tuple<Utf8,Uint64>",
+		},
+		{
+			typ: &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+				Type: &Ydb.VariantType_StructItems{StructItems: &Ydb.StructType{
+					Members: []*Ydb.StructMember{{Name: "a", Type: utf8T}},
+				}},
+			}}},
+			want: "Variant<a:Utf8>",
+		},
+		{
+			typ:  &Ydb.Type{Type: &Ydb.Type_TaggedType{TaggedType: &Ydb.TaggedType{Tag: "tag", Type: utf8T}}},
+			want: `Tagged<Utf8,"tag">`,
+		},
+		{typ: &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: 23}}}, want: "PgInt4"},
+		{typ: &Ydb.Type{Type: &Ydb.Type_VoidType{}}, want: "Void"},
+		{typ: nil, want: "Unknown"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatType(tt.typ))
+	}
+
+	// quoted names are parsed back as is
+	for _, name := range []string{`a\b`, `c\`, "d`", "\\`"} {
+		typ := &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{
+			Members: []*Ydb.StructMember{{Name: name, Type: utf8T}},
+		}}}
+		parsed, err := ParseType(FormatType(typ))
+		require.NoError(t, err, name)
+		assert.Equal(t, name, parsed.GetStructType().GetMembers()[0].GetName())
+	}
+}
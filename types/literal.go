@@ -0,0 +1,348 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const (
+	literalDateLayout = "2006-01-02"
+	literalTimeLayout = "2006-01-02T15:04:05Z"
+	literalTsLayout   = "2006-01-02T15:04:05.000000Z"
+)
+
+var (
+	ErrLiteral = errors.New("cannot render literal")
+)
+
+// Literal renders value as YQL expression, for example Uint64("123"),
+// Just(Utf8("text")) or AsList(AsStruct(Uint64("1") AS id)).
+// Values of primitive types are rendered as typed literals, containers are built
+// with AsList, AsStruct, AsTuple and AsDict, NULLs are rendered as Nothing of their type.
+// PostgreSQL types are not supported.
+func Literal(tv *Ydb.TypedValue) (string, error) {
+	var b strings.Builder
+	if err := writeLiteral(&b, tv.GetType(), tv.GetValue()); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+//nolint:cyclop,gocognit,funlen // flat switch over type kinds
+func writeLiteral(b *strings.Builder, typ *Ydb.Type, val *Ydb.Value) error {
+	if val == nil {
+		return fmt.Errorf("%w: value is not set, expected %s", ErrLiteral, FormatType(typ))
+	}
+
+	switch t := typ.GetType().(type) {
+	case *Ydb.Type_TypeId:
+		if err := checkPrimitive(typ, t.TypeId, val); err != nil {
+			return errors.Join(ErrLiteral, err)
+		}
+
+		return writePrimitive(b, typ, t.TypeId, val)
+	case *Ydb.Type_DecimalType:
+		dec, err := DecodeDecimal(typ, val)
+		if err != nil {
+			return errors.Join(ErrLiteral, err)
+		}
+		fmt.Fprintf(b, "Decimal(%s, %d, %d)", quoteLiteral(dec.String()), dec.Precision, dec.Scale)
+	case *Ydb.Type_OptionalType:
+		item := t.OptionalType.GetItem()
+		switch v := val.GetValue().(type) {
+		case *Ydb.Value_NullFlagValue:
+			b.WriteString("Nothing(")
+			b.WriteString(FormatType(typ))
+			b.WriteByte(')')
+
+			return nil
+		case *Ydb.Value_NestedValue:
+			if _, nested := item.GetType().(*Ydb.Type_OptionalType); nested {
+				val = v.NestedValue
+			}
+		}
+		b.WriteString("Just(")
+		if err := writeLiteral(b, item, val); err != nil {
+			return err
+		}
+		b.WriteByte(')')
+	case *Ydb.Type_ListType:
+		if len(val.GetItems()) == 0 {
+			b.WriteString("ListCreate(")
+			b.WriteString(FormatType(t.ListType.GetItem()))
+			b.WriteByte(')')
+
+			return nil
+		}
+		b.WriteString("AsList(")
+		for i, item := range val.GetItems() {
+			writeSep(b, i)
+			if err := writeLiteral(b, t.ListType.GetItem(), item); err != nil {
+				return WithPath(err, indexSegment(i))
+			}
+		}
+		b.WriteByte(')')
+	case *Ydb.Type_TupleType:
+		elements := t.TupleType.GetElements()
+		if len(val.GetItems()) != len(elements) {
+			return fmt.Errorf("%w: %d items, expected %s", ErrLiteral, len(val.GetItems()), FormatType(typ))
+		}
+		b.WriteString("AsTuple(")
+		for i, item := range val.GetItems() {
+			writeSep(b, i)
+			if err := writeLiteral(b, elements[i], item); err != nil {
+				return WithPath(err, indexSegment(i))
+			}
+		}
+		b.WriteByte(')')
+	case *Ydb.Type_StructType:
+		members := t.StructType.GetMembers()
+		if len(val.GetItems()) != len(members) {
+			return fmt.Errorf("%w: %d members, expected %s", ErrLiteral, len(val.GetItems()), FormatType(typ))
+		}
+		b.WriteString("AsStruct(")
+		for i, item := range val.GetItems() {
+			writeSep(b, i)
+			if err := writeLiteral(b, members[i].GetType(), item); err != nil {
+				return WithPath(err, memberSegment(members[i].GetName()))
+			}
+			b.WriteString(" AS ")
+			formatName(b, members[i].GetName())
+		}
+		b.WriteByte(')')
+	case *Ydb.Type_DictType:
+		if len(val.GetPairs()) == 0 {
+			b.WriteString("DictCreate(")
+			b.WriteString(FormatType(t.DictType.GetKey()))
+			b.WriteString(", ")
+			b.WriteString(FormatType(t.DictType.GetPayload()))
+			b.WriteByte(')')
+
+			return nil
+		}
+		b.WriteString("AsDict(")
+		for i, pair := range val.GetPairs() {
+			writeSep(b, i)
+			b.WriteString("AsTuple(")
+			if err := writeLiteral(b, t.DictType.GetKey(), pair.GetKey()); err != nil {
+				return WithPath(err, indexSegment(i)+".key")
+			}
+			b.WriteString(", ")
+			if err := writeLiteral(b, t.DictType.GetPayload(), pair.GetPayload()); err != nil {
+				return WithPath(err, indexSegment(i))
+			}
+			b.WriteByte(')')
+		}
+		b.WriteByte(')')
+	case *Ydb.Type_VariantType:
+		return writeVariant(b, typ, t.VariantType, val)
+	case *Ydb.Type_TaggedType:
+		b.WriteString("AsTagged(")
+		if err := writeLiteral(b, t.TaggedType.GetType(), val); err != nil {
+			return err
+		}
+		b.WriteString(", ")
+		b.WriteString(quoteLiteral(t.TaggedType.GetTag()))
+		b.WriteByte(')')
+	case *Ydb.Type_VoidType:
+		b.WriteString("Void()")
+	case *Ydb.Type_NullType:
+		b.WriteString("NULL")
+	case *Ydb.Type_EmptyListType:
+		b.WriteString("AsList()")
+	case *Ydb.Type_EmptyDictType:
+		b.WriteString("AsDict()")
+	default:
+		return fmt.Errorf("%w: %s is not supported", ErrLiteral, FormatType(typ))
+	}
+
+	return nil
+}
+
+func writeVariant(b *strings.Builder, typ *Ydb.Type, vt *Ydb.VariantType, val *Ydb.Value) error {
+	nested, ok := val.GetValue().(*Ydb.Value_NestedValue)
+	if !ok {
+		return fmt.Errorf("%w: expected %s, got %s", ErrLiteral, FormatType(typ), valueKind(val))
+	}
+
+	var (
+		idx         = val.GetVariantIndex()
+		alternative *Ydb.Type
+		name        = strconv.FormatUint(uint64(idx), 10)
+		segment     = indexSegment(int(idx))
+	)
+	switch items := vt.GetType().(type) {
+	case *Ydb.VariantType_TupleItems:
+		if elements := items.TupleItems.GetElements(); int(idx) < len(elements) {
+			alternative = elements[idx]
+		}
+	case *Ydb.VariantType_StructItems:
+		if members := items.StructItems.GetMembers(); int(idx) < len(members) {
+			alternative, name = members[idx].GetType(), members[idx].GetName()
+			segment = memberSegment(name)
+		}
+	}
+	if alternative == nil {
+		return fmt.Errorf("%w: %w: index %d, expected %s", ErrLiteral, ErrVariant, idx, FormatType(typ))
+	}
+
+	b.WriteString("Variant(")
+	if err := writeLiteral(b, alternative, nested.NestedValue); err != nil {
+		return WithPath(err, segment)
+	}
+	b.WriteString(", ")
+	b.WriteString(quoteLiteral(name))
+	b.WriteString(", ")
+	b.WriteString(FormatType(typ))
+	b.WriteByte(')')
+
+	return nil
+}
+
+//nolint:cyclop // flat switch over primitive types
+func writePrimitive(b *strings.Builder, typ *Ydb.Type, id Ydb.Type_PrimitiveTypeId, val *Ydb.Value) error {
+	var text string
+	switch id { //nolint:exhaustive // other types are handled below
+	case Ydb.Type_BOOL:
+		b.WriteString(strconv.FormatBool(val.GetBoolValue()))
+
+		return nil
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32:
+		text = strconv.FormatInt(int64(val.GetInt32Value()), 10)
+	case Ydb.Type_INT64:
+		text = strconv.FormatInt(val.GetInt64Value(), 10)
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32:
+		text = strconv.FormatUint(uint64(val.GetUint32Value()), 10)
+	case Ydb.Type_UINT64:
+		text = strconv.FormatUint(val.GetUint64Value(), 10)
+	case Ydb.Type_FLOAT:
+		text = formatFloat(float64(val.GetFloatValue()), 32) //nolint:mnd // bit size
+	case Ydb.Type_DOUBLE:
+		text = formatFloat(val.GetDoubleValue(), 64) //nolint:mnd // bit size
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER,
+		Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP:
+		text = val.GetTextValue()
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		text = string(val.GetBytesValue())
+	case Ydb.Type_UUID:
+		u, err := DecodeUUID(typ, val)
+		if err != nil {
+			return errors.Join(ErrLiteral, err)
+		}
+		text = u.String()
+	default:
+		var err error
+		if text, err = temporalText(typ, id, val); err != nil {
+			return err
+		}
+	}
+
+	b.WriteString(primitiveNames[id])
+	b.WriteByte('(')
+	b.WriteString(quoteLiteral(text))
+	b.WriteByte(')')
+
+	return nil
+}
+
+// temporalText returns text representation of date, time or interval value.
+func temporalText(typ *Ydb.Type, id Ydb.Type_PrimitiveTypeId, val *Ydb.Value) (string, error) {
+	if IsDuration(id) {
+		d, err := DecodeDuration(typ, val)
+		if err != nil {
+			return "", errors.Join(ErrLiteral, err)
+		}
+
+		return isoDuration(d), nil
+	}
+
+	t, err := DecodeTime(typ, val)
+	if err != nil {
+		return "", errors.Join(ErrLiteral, err)
+	}
+	switch id { //nolint:exhaustive // only time types are passed
+	case Ydb.Type_DATE, TypeDate32:
+		return t.Format(literalDateLayout), nil
+	case Ydb.Type_DATETIME, TypeDatetime64:
+		return t.Format(literalTimeLayout), nil
+	case Ydb.Type_TIMESTAMP, TypeTimestamp64:
+		return t.Format(literalTsLayout), nil
+	}
+
+	return "", fmt.Errorf("%w: %s is not supported", ErrLiteral, FormatType(typ))
+}
+
+// isoDuration formats d as ISO 8601 duration with seconds, for example -PT90.5S.
+func isoDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+	}
+	usec := d.Microseconds()
+	if usec < 0 {
+		usec = -usec
+	}
+
+	text := strconv.FormatInt(usec/usecPerSec, 10)
+	if frac := usec % usecPerSec; frac > 0 {
+		text += strings.TrimRight(fmt.Sprintf(".%06d", frac), "0")
+	}
+
+	return sign + "PT" + text + "S"
+}
+
+func formatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// quoteLiteral quotes s as YQL string literal. Non-printable characters
+// and bytes of invalid UTF-8 sequences are escaped.
+func quoteLiteral(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == utf8.RuneError && size == 1, r < ' ', r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, s[i])
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func writeSep(b *strings.Builder, i int) {
+	if i > 0 {
+		b.WriteString(", ")
+	}
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestLiteral(t *testing.T) {
+	ts := time.Date(2024, 9, 20, 12, 30, 0, 500000000, time.UTC)
+	tsVal, err := Timestamp(ts)
+	require.NoError(t, err)
+	date, err := Date(ts)
+	require.NoError(t, err)
+	interval, err := Interval(-90*time.Second - 500*time.Millisecond)
+	require.NoError(t, err)
+	dec, err := DecimalFromString("1.5", 22, 2)
+	require.NoError(t, err)
+	tags, err := List(UTF8("a"), UTF8("b"))
+	require.NoError(t, err)
+	empty, err := ListOf(Uint64(0).Type)
+	require.NoError(t, err)
+	dict, err := Dict(Pair(Bytes([]byte("k")), NullOf(Int32(0).Type)))
+	require.NoError(t, err)
+	variant, err := VariantStruct("err", UTF8("failed"),
+		&Ydb.StructMember{Name: "ok", Type: Uint64(0).Type}, &Ydb.StructMember{Name: "err", Type: UTF8("").Type})
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		tv   *Ydb.TypedValue
+		want string
+	}{
+		{tv: Bool(true), want: `true`},
+		{tv: Int32(-1), want: `Int32("-1")`},
+		{tv: Uint64(123), want: `Uint64("123")`},
+		{tv: Double(1.5), want: `Double("1.5")`},
+		{tv: Double(math.Inf(-1)), want: `Double("-inf")`},
+		{tv: UTF8("say \"hi\"\n"), want: `Utf8("say \"hi\"\n")`},
+		{tv: Bytes([]byte{'a', 0xff, 0}), want: `String("a\xff\x00")`},
+		{tv: JSON(`{"a":1}`), want: `Json("{\"a\":1}")`},
+		{tv: UUID([16]byte{1}), want: `Uuid("01000000-0000-0000-0000-000000000000")`},
+		{tv: date, want: `Date("2024-09-20")`},
+		{tv: tsVal, want: `Timestamp("2024-09-20T12:30:00.500000Z")`},
+		{tv: interval, want: `Interval("-PT90.5S")`},
+		{tv: Interval64(0), want: `Interval64("PT0S")`},
+		{tv: dec, want: `Decimal("1.50", 22, 2)`},
+		{tv: Optional(Optional(UTF8("x"))), want: `Just(Just(Utf8("x")))`},
+		{tv: NullOf(UTF8("").Type), want: `Nothing(Optional<Utf8>)`},
+		{tv: empty, want: `ListCreate(Uint64)`},
+		{tv: Tuple(Bool(false), tags), want: `AsTuple(false, AsList(Utf8("a"), Utf8("b")))`},
+		{tv: Struct(Field("id", Uint64(1)), Field("my tag", UTF8("a"))), want: "AsStruct(Uint64(\"1\") AS id, Utf8(\"a\") AS `my tag`)"},
+		{tv: dict, want: `AsDict(AsTuple(String("k"), Nothing(Optional<Int32>)))`},
+		{tv: variant, want: `Variant(Utf8("failed"), "err", Variant<ok:Uint64,err:Utf8>)`},
+	} {
+		got, errL := Literal(tt.tv)
+		require.NoError(t, errL, FormatType(tt.tv.Type))
+		assert.Equal(t, tt.want, got, FormatType(tt.tv.Type))
+	}
+}
+
+func TestLiteral_Error(t *testing.T) {
+	tags, err := List(UTF8("a"), UTF8("b"))
+	require.NoError(t, err)
+	tags.Value.Items[1] = Uint64(1).Value
+
+	_, err = Literal(Struct(Field("tags", tags)))
+	require.ErrorIs(t, err, ErrLiteral)
+	assert.Contains(t, err.Error(), ".tags[1]: ")
+
+	_, err = Literal(&Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: 23}}},
+		Value: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "1"}},
+	})
+	require.ErrorIs(t, err, ErrLiteral)
+}
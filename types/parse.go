@@ -0,0 +1,404 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrParse = errors.New("cannot parse type")
+)
+
+var (
+	primitiveByLowerName = lowerNames(primitiveNames)
+	pgByLowerName        = lowerNames(pgNames)
+)
+
+// typeParser is a recursive descent parser of YQL type expressions.
+type typeParser struct {
+	s   string
+	pos int
+}
+
+// ParseType parses type in YQL syntax, for example Optional<List<Struct<id:Uint64,name:Utf8>>>.
+// Type names are case-insensitive, T? is a shorthand for Optional<T> and Set<T> is Dict<T,Void>.
+// Struct member names can be quoted with backticks.
+func ParseType(s string) (*Ydb.Type, error) {
+	p := &typeParser{s: s}
+
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	return t, nil
+}
+
+func (p *typeParser) parseType() (*Ydb.Type, error) {
+	name := p.ident()
+	if name == "" {
+		return nil, p.errorf("type name expected")
+	}
+
+	t, err := p.parseNamed(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.consume('?') {
+		t = optionalType(t)
+	}
+
+	return t, nil
+}
+
+//nolint:cyclop // flat switch over type names
+func (p *typeParser) parseNamed(name string) (*Ydb.Type, error) {
+	switch lower := strings.ToLower(name); lower {
+	case "decimal":
+		return p.parseDecimal()
+	case "optional":
+		item, err := p.parseItems(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return optionalType(item[0]), nil
+	case "list":
+		item, err := p.parseItems(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{Item: item[0]}}}, nil
+	case "set":
+		item, err := p.parseItems(1)
+		if err != nil {
+			return nil, err
+		}
+
+		return dictType(item[0], &Ydb.Type{Type: &Ydb.Type_VoidType{}}), nil
+	case "dict":
+		items, err := p.parseItems(2) //nolint:mnd // key and payload
+		if err != nil {
+			return nil, err
+		}
+
+		return dictType(items[0], items[1]), nil
+	case "tuple":
+		items, err := p.parseItems(-1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: items}}}, nil
+	case "struct":
+		members, err := p.parseMembers()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{Members: members}}}, nil
+	case "variant":
+		return p.parseVariant()
+	case "tagged":
+		return p.parseTagged()
+	case "void":
+		return &Ydb.Type{Type: &Ydb.Type_VoidType{}}, nil
+	case "null":
+		return &Ydb.Type{Type: &Ydb.Type_NullType{}}, nil
+	case "emptylist":
+		return &Ydb.Type{Type: &Ydb.Type_EmptyListType{}}, nil
+	case "emptydict":
+		return &Ydb.Type{Type: &Ydb.Type_EmptyDictType{}}, nil
+	default:
+		if id, ok := primitiveByLowerName[lower]; ok {
+			return &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}}, nil
+		}
+		if oid, ok := pgByLowerName[lower]; ok {
+			return &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: oid}}}, nil
+		}
+
+		return nil, p.errorf("unknown type %q", name)
+	}
+}
+
+func (p *typeParser) parseDecimal() (*Ydb.Type, error) {
+	if !p.consume('(') {
+		return nil, p.errorf("'(' expected")
+	}
+	precision, err := p.number()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("',' expected")
+	}
+	scale, err := p.number()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(')') {
+		return nil, p.errorf("')' expected")
+	}
+	if err = checkDecimalType(precision, scale); err != nil {
+		return nil, errors.Join(ErrParse, err)
+	}
+
+	return &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{
+		Precision: precision,
+		Scale:     scale,
+	}}}, nil
+}
+
+// parseItems parses comma separated types in angle brackets.
+// If n is not negative, exactly n types are expected, otherwise list can be empty.
+func (p *typeParser) parseItems(n int) ([]*Ydb.Type, error) {
+	if !p.consume('<') {
+		return nil, p.errorf("'<' expected")
+	}
+
+	var items []*Ydb.Type
+	if n < 0 && p.consume('>') {
+		return items, nil
+	}
+	for {
+		item, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if !p.consume(',') {
+			break
+		}
+	}
+	if !p.consume('>') {
+		return nil, p.errorf("'>' expected")
+	}
+	if n >= 0 && len(items) != n {
+		return nil, p.errorf("%d type parameters expected, got %d", n, len(items))
+	}
+
+	return items, nil
+}
+
+// parseMembers parses comma separated name:type pairs in angle brackets.
+func (p *typeParser) parseMembers() ([]*Ydb.StructMember, error) {
+	if !p.consume('<') {
+		return nil, p.errorf("'<' expected")
+	}
+
+	var members []*Ydb.StructMember
+	if p.consume('>') {
+		return members, nil
+	}
+	for {
+		name, err := p.memberName()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(':') {
+			return nil, p.errorf("':' expected")
+		}
+		t, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &Ydb.StructMember{Name: name, Type: t})
+		if !p.consume(',') {
+			break
+		}
+	}
+	if !p.consume('>') {
+		return nil, p.errorf("'>' expected")
+	}
+
+	return members, nil
+}
+
+// parseVariant parses variant over tuple or struct. Kind of variant
+// is determined by ':' after first item name.
+func (p *typeParser) parseVariant() (*Ydb.Type, error) {
+	start := p.pos
+	if p.consume('<') {
+		_, errName := p.memberName()
+		isStruct := errName == nil && p.consume(':')
+		p.pos = start
+
+		if isStruct {
+			members, err := p.parseMembers()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+				Type: &Ydb.VariantType_StructItems{StructItems: &Ydb.StructType{Members: members}},
+			}}}, nil
+		}
+	}
+
+	items, err := p.parseItems(-1)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, p.errorf("variant without alternatives")
+	}
+
+	return &Ydb.Type{Type: &Ydb.Type_VariantType{VariantType: &Ydb.VariantType{
+		Type: &Ydb.VariantType_TupleItems{TupleItems: &Ydb.TupleType{Elements: items}},
+	}}}, nil
+}
+
+func (p *typeParser) parseTagged() (*Ydb.Type, error) {
+	if !p.consume('<') {
+		return nil, p.errorf("'<' expected")
+	}
+	t, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("',' expected")
+	}
+	tag, err := p.quoted()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume('>') {
+		return nil, p.errorf("'>' expected")
+	}
+
+	return &Ydb.Type{Type: &Ydb.Type_TaggedType{TaggedType: &Ydb.TaggedType{Tag: tag, Type: t}}}, nil
+}
+
+func (p *typeParser) skipSpace() {
+	for p.pos < len(p.s) && isSpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+// consume skips spaces and c if it is the next character.
+func (p *typeParser) consume(c byte) bool {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *typeParser) ident() string {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (p.pos == start || c < '0' || c > '9') {
+			break
+		}
+		p.pos++
+	}
+
+	return p.s[start:p.pos]
+}
+
+func (p *typeParser) memberName() (string, error) {
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '`' {
+		var b strings.Builder
+		for i := p.pos + 1; i < len(p.s); i++ {
+			switch {
+			case p.s[i] == '\\' && i+1 < len(p.s):
+				i++
+				b.WriteByte(p.s[i])
+			case p.s[i] == '`':
+				p.pos = i + 1
+				return b.String(), nil
+			default:
+				b.WriteByte(p.s[i])
+			}
+		}
+
+		return "", p.errorf("unterminated name")
+	}
+
+	name := p.ident()
+	if name == "" {
+		return "", p.errorf("member name expected")
+	}
+
+	return name, nil
+}
+
+func (p *typeParser) number() (uint32, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.ParseUint(p.s[start:p.pos], 10, 32)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("number expected")
+	}
+
+	return uint32(n), nil
+}
+
+// quoted parses string literal in double or single quotes.
+func (p *typeParser) quoted() (string, error) {
+	p.skipSpace()
+	if p.pos >= len(p.s) || (p.s[p.pos] != '"' && p.s[p.pos] != '\'') {
+		return "", p.errorf("quoted string expected")
+	}
+	quote := p.s[p.pos]
+	for i := p.pos + 1; i < len(p.s); i++ {
+		switch p.s[i] {
+		case '\\':
+			i++
+		case quote:
+			lit := p.s[p.pos : i+1]
+			if quote == '\'' {
+				lit = `"` + strings.ReplaceAll(lit[1:len(lit)-1], `"`, `\"`) + `"`
+			}
+			s, err := strconv.Unquote(lit)
+			if err != nil {
+				return "", p.errorf("invalid string %s", p.s[p.pos:i+1])
+			}
+			p.pos = i + 1
+
+			return s, nil
+		}
+	}
+
+	return "", p.errorf("unterminated string")
+}
+
+func (p *typeParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w %q at %d: %s", ErrParse, p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func dictType(key, payload *Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{Key: key, Payload: payload}}}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func lowerNames[K comparable](names map[K]string) map[string]K {
+	byName := make(map[string]K, len(names))
+	for k, name := range names {
+		byName[strings.ToLower(name)] = k
+	}
+
+	return byName
+}
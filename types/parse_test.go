@@ -0,0 +1,54 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseType(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{in: "Uint64", want: "Uint64"},
+		{in: "utf8", want: "Utf8"},
+		{in: "Decimal( 22, 9 )", want: "Decimal(22,9)"},
+		{in: "Optional<List<Struct<id:Uint64,name:Utf8>>>", want: "Optional<List<Struct<id:Uint64,name:Utf8>>>"},
+		{in: "Struct< `user name` : Utf8?, id: Int32 >", want: "Struct<`user name`:Optional<Utf8>,id:Int32>"},
+		{in: "Struct<>", want: "Struct<>"},
+		{in: "Struct<`a\\\\b`:Utf8>", want: "Struct<`a\\\\b`:Utf8>"},
+		{in: "Struct<`a\\``:Utf8,`b\\\\`:Utf8>", want: "Struct<`a\\``:Utf8,`b\\\\`:Utf8>"},
+		{in: "Int32??", want: "Optional<Optional<Int32>>"},
+		{in: "Dict<Utf8,Timestamp64>", want: "Dict<Utf8,Timestamp64>"},
+		{in: "Set<Uuid>", want: "Dict<Uuid,Void>"},
+		{in: "Tuple<Utf8,Uint64,TzDate>", want: "Tuple<Utf8,Uint64,TzDate>"},
+		{in: "Tuple< >", want: "Tuple<>"},
+		{in: "List<Tuple<>>", want: "List<Tuple<>>"},
+		{in: "Variant<Utf8,Uint64>", want: "Variant<Utf8,Uint64>"},
+		{in: "Variant<ok:Utf8,err:Uint64>", want: "Variant<ok:Utf8,err:Uint64>"},
+		{in: `Tagged<Utf8,'my "tag"'>`, want: `Tagged<Utf8,"my \"tag\"">`},
+		{in: "PgInt4", want: "PgInt4"},
+		{in: "EmptyList", want: "EmptyList"},
+		{in: " Null ", want: "Null"},
+	} {
+		typ, err := ParseType(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, FormatType(typ), tt.in)
+
+		// formatted type is parsed back to the same type
+		again, err := ParseType(FormatType(typ))
+		require.NoError(t, err, tt.in)
+		assert.True(t, proto.Equal(typ, again), tt.in)
+	}
+
+	for _, in := range []string{
+		"", "Varchar", "List<Utf8", "List<Utf8,Uint64>", "Dict<Utf8>", "Struct<id Utf8>",
+		"Decimal(40,2)", "Decimal(22)", "Tuple<Utf8,>", "List<>", "Dict<>", "Variant<>", "Tagged<Utf8,tag>", "Uint64 Utf8", "Struct<`id:Utf8>",
+	} {
+		_, err := ParseType(in)
+		require.ErrorIs(t, err, ErrParse, in)
+	}
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// Oids of PostgreSQL types.
+const (
+	PgBool      uint32 = 16
+	PgBytea     uint32 = 17
+	PgInt8      uint32 = 20
+	PgInt2      uint32 = 21
+	PgInt4      uint32 = 23
+	PgText      uint32 = 25
+	PgFloat4    uint32 = 700
+	PgFloat8    uint32 = 701
+	PgTimestamp uint32 = 1114
+	PgInterval  uint32 = 1186
+)
+
+const (
+	pgTimestampLayout = "2006-01-02 15:04:05.999999"
+)
+
+// Pg creates PostgreSQL value of type with provided oid from its text representation.
+func Pg(oid uint32, text string) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: oid}}},
+		Value: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: text}},
+	}
+}
+
+// PgNull creates NULL PostgreSQL value of type with provided oid.
+func PgNull(oid uint32) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_PgType{PgType: &Ydb.PgType{Oid: oid}}},
+		Value: &Ydb.Value{Value: &Ydb.Value_NullFlagValue{}},
+	}
+}
+
+// PgFromGo converts Go value to PostgreSQL value. Type is inferred from Go type:
+// integers are converted to int2, int4 or int8, floats to float4 or float8, string to text,
+// []byte to bytea, time.Time to timestamp and time.Duration to interval.
+// driver.Valuer implementations are converted using returned value.
+// Nil pointers are converted to NULL of corresponding type, untyped nil to NULL text.
+//
+//nolint:cyclop // flat switch over supported types
+func PgFromGo(v any) (*Ydb.TypedValue, error) {
+	switch val := v.(type) {
+	case *Ydb.TypedValue:
+		return val, nil
+	case nil:
+		return PgNull(PgText), nil
+	case driver.Valuer:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return PgNull(PgText), nil
+		}
+		dv, err := val.Value()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // user defined error
+		}
+
+		return PgFromGo(dv)
+	case bool:
+		return Pg(PgBool, strconv.FormatBool(val)), nil
+	case int8:
+		return Pg(PgInt2, strconv.FormatInt(int64(val), 10)), nil
+	case int16:
+		return Pg(PgInt2, strconv.FormatInt(int64(val), 10)), nil
+	case int32:
+		return Pg(PgInt4, strconv.FormatInt(int64(val), 10)), nil
+	case int64:
+		return Pg(PgInt8, strconv.FormatInt(val, 10)), nil
+	case int:
+		return Pg(PgInt8, strconv.FormatInt(int64(val), 10)), nil
+	case uint8:
+		return Pg(PgInt2, strconv.FormatUint(uint64(val), 10)), nil
+	case uint16:
+		return Pg(PgInt4, strconv.FormatUint(uint64(val), 10)), nil
+	case uint32:
+		return Pg(PgInt8, strconv.FormatUint(uint64(val), 10)), nil
+	case uint64:
+		if val > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d overflows int8", ErrGoType, val)
+		}
+
+		return Pg(PgInt8, strconv.FormatUint(val, 10)), nil
+	case float32:
+		return Pg(PgFloat4, pgFloat(float64(val), 32)), nil //nolint:mnd // bit size
+	case float64:
+		return Pg(PgFloat8, pgFloat(val, 64)), nil //nolint:mnd // bit size
+	case string:
+		return Pg(PgText, val), nil
+	case []byte:
+		return Pg(PgBytea, `\x`+hex.EncodeToString(val)), nil
+	case time.Time:
+		return Pg(PgTimestamp, val.UTC().Format(pgTimestampLayout)), nil
+	case time.Duration:
+		return Pg(PgInterval, strconv.FormatInt(val.Microseconds(), 10)+" microseconds"), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer {
+		return nil, fmt.Errorf("%w: %T", ErrGoType, v)
+	}
+
+	if rv.IsNil() {
+		item, err := PgFromGo(reflect.Zero(rv.Type().Elem()).Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		return PgNull(item.Type.GetPgType().GetOid()), nil
+	}
+
+	return PgFromGo(rv.Elem().Interface())
+}
+
+func pgFloat(v float64, bitSize int) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "Infinity"
+	case math.IsInf(v, -1):
+		return "-Infinity"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, bitSize)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgFromGo(t *testing.T) {
+	ts := time.Date(2024, 9, 20, 12, 30, 15, 123000, time.UTC)
+	num := int32(5)
+
+	for _, tt := range []struct {
+		arg      any
+		wantType string
+		want     string
+	}{
+		{arg: true, wantType: "PgBool", want: "true"},
+		{arg: int16(-3), wantType: "PgInt2", want: "-3"},
+		{arg: int32(7), wantType: "PgInt4", want: "7"},
+		{arg: 42, wantType: "PgInt8", want: "42"},
+		{arg: uint32(math.MaxUint32), wantType: "PgInt8", want: "4294967295"},
+		{arg: 1.5, wantType: "PgFloat8", want: "1.5"},
+		{arg: math.Inf(-1), wantType: "PgFloat8", want: "-Infinity"},
+		{arg: float32(0.1), wantType: "PgFloat4", want: "0.1"},
+		{arg: "test", wantType: "PgText", want: "test"},
+		{arg: []byte{0xde, 0xad}, wantType: "PgBytea", want: `\xdead`},
+		{arg: ts, wantType: "PgTimestamp", want: "2024-09-20 12:30:15.000123"},
+		{arg: &num, wantType: "PgInt4", want: "5"},
+	} {
+		tv, err := PgFromGo(tt.arg)
+		require.NoError(t, err, "%T", tt.arg)
+		assert.Equal(t, tt.wantType, FormatType(tv.Type), "%T", tt.arg)
+		assert.Equal(t, tt.want, tv.Value.GetTextValue(), "%T", tt.arg)
+	}
+
+	assert.Equal(t, PgNull(PgInt4), must(PgFromGo((*int32)(nil))))
+	assert.Equal(t, PgNull(PgText), must(PgFromGo(nil)))
+
+	_, err := PgFromGo(uint64(math.MaxUint64))
+	require.ErrorIs(t, err, ErrGoType)
+
+	_, err = PgFromGo(struct{}{})
+	require.ErrorIs(t, err, ErrGoType)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
@@ -0,0 +1,325 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// Primitive type ids of wide date and time types. They have extended range
+// and are encoded as signed values. Ids are not yet defined in genproto.
+const (
+	TypeDate32      = Ydb.Type_PrimitiveTypeId(0x0040)
+	TypeDatetime64  = Ydb.Type_PrimitiveTypeId(0x0041)
+	TypeTimestamp64 = Ydb.Type_PrimitiveTypeId(0x0042)
+	TypeInterval64  = Ydb.Type_PrimitiveTypeId(0x0043)
+)
+
+// Ranges of date and time types. Narrow types support values in [1970-01-01, 2106-01-01),
+// intervals are limited by the same amount of time in both directions.
+// Wide types support years from -144169 to 148107.
+const (
+	maxDate      = 49673
+	maxDatetime  = maxDate * secPerDay
+	maxTimestamp = maxDatetime * usecPerSec
+
+	minDate32     = -53375809
+	maxDate32     = 53375807
+	minDatetime64 = minDate32 * secPerDay
+	maxDatetime64 = (maxDate32+1)*secPerDay - 1
+
+	maxDuration = time.Duration(math.MaxInt64)
+	minDuration = time.Duration(math.MinInt64)
+
+	usecPerSec = 1000000
+)
+
+const (
+	tzDateLayout  = "2006-01-02"
+	tzTimeLayout  = "2006-01-02T15:04:05"
+	tzTsLayout    = "2006-01-02T15:04:05.000000"
+	tzSeparator   = ","
+	localTimeZone = "Local"
+)
+
+var (
+	ErrRange    = errors.New("value is out of range")
+	ErrTimeZone = errors.New("unsupported time zone")
+	ErrDecode   = errors.New("cannot decode value")
+)
+
+// Date creates Date value from calendar date of t in its location.
+// Supported dates are from 1970-01-01 to 2105-12-31.
+func Date(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_DATE)
+}
+
+// Date32 creates Date32 value from calendar date of t in its location.
+// Dates before Unix epoch are supported.
+func Date32(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, TypeDate32)
+}
+
+// Datetime creates Datetime value from t with seconds precision.
+// Supported time is from 1970-01-01T00:00:00Z to 2105-12-31T23:59:59Z.
+func Datetime(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_DATETIME)
+}
+
+// Datetime64 creates Datetime64 value from t with seconds precision.
+// Time before Unix epoch is supported.
+func Datetime64(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, TypeDatetime64)
+}
+
+// Timestamp creates Timestamp value from t with microseconds precision.
+// Supported time is from 1970-01-01T00:00:00Z to 2105-12-31T23:59:59.999999Z.
+func Timestamp(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_TIMESTAMP)
+}
+
+// Timestamp64 creates Timestamp64 value from t with microseconds precision.
+// Time before Unix epoch is supported.
+func Timestamp64(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, TypeTimestamp64)
+}
+
+// TzDate creates TzDate value from calendar date of t and name of its location.
+func TzDate(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_TZ_DATE)
+}
+
+// TzDatetime creates TzDatetime value from t and name of its location.
+func TzDatetime(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_TZ_DATETIME)
+}
+
+// TzTimestamp creates TzTimestamp value from t and name of its location.
+func TzTimestamp(t time.Time) (*Ydb.TypedValue, error) {
+	return temporal(t, Ydb.Type_TZ_TIMESTAMP)
+}
+
+// Interval creates Interval value from d with microseconds precision.
+// Absolute value of d must be less than 49673 days.
+func Interval(d time.Duration) (*Ydb.TypedValue, error) {
+	return temporal(d, Ydb.Type_INTERVAL)
+}
+
+// Interval64 creates Interval64 value from d with microseconds precision.
+// Any time.Duration fits into Interval64.
+func Interval64(d time.Duration) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: TypeInterval64}},
+		Value: &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: d.Microseconds()}},
+	}
+}
+
+func temporal[T time.Time | time.Duration](v T, id Ydb.Type_PrimitiveTypeId) (*Ydb.TypedValue, error) {
+	var (
+		val *Ydb.Value
+		err error
+	)
+	switch tv := any(v).(type) {
+	case time.Time:
+		val, err = timeValue(tv, id)
+	case time.Duration:
+		val, err = durationValue(tv, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: id}},
+		Value: val,
+	}, nil
+}
+
+// IsTime reports whether values of type id are decoded to time.Time.
+func IsTime(id Ydb.Type_PrimitiveTypeId) bool {
+	switch id { //nolint:exhaustive // other types are not time
+	case Ydb.Type_DATE, Ydb.Type_DATETIME, Ydb.Type_TIMESTAMP,
+		Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP,
+		TypeDate32, TypeDatetime64, TypeTimestamp64:
+		return true
+	}
+
+	return false
+}
+
+// IsDuration reports whether values of type id are decoded to time.Duration.
+func IsDuration(id Ydb.Type_PrimitiveTypeId) bool {
+	return id == Ydb.Type_INTERVAL || id == TypeInterval64
+}
+
+// timeValue encodes t as value of date or time type checking its range.
+//
+//nolint:cyclop // flat switch over time types
+func timeValue(t time.Time, id Ydb.Type_PrimitiveTypeId) (*Ydb.Value, error) {
+	var (
+		val  = &Ydb.Value{}
+		secs = t.Unix()
+		ok   bool
+	)
+
+	switch id { //nolint:exhaustive // other types are not time
+	case Ydb.Type_DATE:
+		days := daysOf(t)
+		ok = days >= 0 && days < maxDate
+		val.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(days)} //nolint:gosec // range is checked
+	case TypeDate32:
+		days := daysOf(t)
+		ok = days >= minDate32 && days <= maxDate32
+		val.Value = &Ydb.Value_Int32Value{Int32Value: int32(days)} //nolint:gosec // range is checked
+	case Ydb.Type_DATETIME:
+		ok = secs >= 0 && secs < maxDatetime
+		val.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(secs)} //nolint:gosec // range is checked
+	case TypeDatetime64:
+		ok = secs >= minDatetime64 && secs <= maxDatetime64
+		val.Value = &Ydb.Value_Int64Value{Int64Value: secs}
+	case Ydb.Type_TIMESTAMP:
+		// seconds are checked first since microseconds may overflow int64
+		ok = secs >= 0 && secs < maxDatetime
+		val.Value = &Ydb.Value_Uint64Value{Uint64Value: uint64(t.UnixMicro())} //nolint:gosec // range is checked
+	case TypeTimestamp64:
+		ok = secs >= minDatetime64 && secs <= maxDatetime64
+		val.Value = &Ydb.Value_Int64Value{Int64Value: t.UnixMicro()}
+	case Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP:
+		return tzValue(t, id)
+	default:
+		return nil, fmt.Errorf("%w: cannot convert time.Time to %s", ErrGoType, primitiveNames[id])
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not valid %s", ErrRange, t.Format(time.RFC3339Nano), primitiveNames[id])
+	}
+
+	return val, nil
+}
+
+// tzValue encodes t as text with local time and time zone name, for example
+// 2024-09-20T12:00:00,Europe/Moscow. Range is checked the same way as for types without time zone.
+func tzValue(t time.Time, id Ydb.Type_PrimitiveTypeId) (*Ydb.Value, error) {
+	tz := t.Location().String()
+	if tz == localTimeZone {
+		return nil, fmt.Errorf("%w: name of local time zone is unknown, use time.LoadLocation", ErrTimeZone)
+	}
+
+	var (
+		layout string
+		narrow Ydb.Type_PrimitiveTypeId
+	)
+	switch id { //nolint:exhaustive // only Tz types are passed
+	case Ydb.Type_TZ_DATE:
+		layout, narrow = tzDateLayout, Ydb.Type_DATE
+	case Ydb.Type_TZ_DATETIME:
+		layout, narrow = tzTimeLayout, Ydb.Type_DATETIME
+	default:
+		layout, narrow = tzTsLayout, Ydb.Type_TIMESTAMP
+	}
+	if _, err := timeValue(t, narrow); err != nil {
+		return nil, err
+	}
+
+	return &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: t.Format(layout) + tzSeparator + tz}}, nil
+}
+
+// durationValue encodes d as value of interval type checking its range.
+func durationValue(d time.Duration, id Ydb.Type_PrimitiveTypeId) (*Ydb.Value, error) {
+	usec := d.Microseconds()
+	switch id { //nolint:exhaustive // other types are not interval
+	case Ydb.Type_INTERVAL:
+		if usec <= -maxTimestamp || usec >= maxTimestamp {
+			return nil, fmt.Errorf("%w: %s is not valid Interval", ErrRange, d)
+		}
+	case TypeInterval64:
+	default:
+		return nil, fmt.Errorf("%w: cannot convert time.Duration to %s", ErrGoType, primitiveNames[id])
+	}
+
+	return &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: usec}}, nil
+}
+
+// daysOf returns number of days between Unix epoch and calendar date of t.
+func daysOf(t time.Time) int64 {
+	y, m, d := t.Date()
+	secs := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix()
+	days := secs / secPerDay
+	if secs%secPerDay != 0 && secs < 0 {
+		days--
+	}
+
+	return days
+}
+
+// DecodeTime decodes value of date or time type. Values of types without time zone
+// are returned in UTC, values of Tz types are returned in their time zone.
+func DecodeTime(typ *Ydb.Type, val *Ydb.Value) (time.Time, error) {
+	id := typ.GetTypeId()
+
+	switch id { //nolint:exhaustive // other types are not time
+	case Ydb.Type_DATE:
+		return time.Unix(int64(val.GetUint32Value())*secPerDay, 0).UTC(), nil
+	case TypeDate32:
+		return time.Unix(int64(val.GetInt32Value())*secPerDay, 0).UTC(), nil
+	case Ydb.Type_DATETIME:
+		return time.Unix(int64(val.GetUint32Value()), 0).UTC(), nil
+	case TypeDatetime64:
+		return time.Unix(val.GetInt64Value(), 0).UTC(), nil
+	case Ydb.Type_TIMESTAMP:
+		if val.GetUint64Value() >= maxTimestamp {
+			return time.Time{}, fmt.Errorf("%w: Timestamp %d is out of range", ErrDecode, val.GetUint64Value())
+		}
+
+		return time.UnixMicro(int64(val.GetUint64Value())).UTC(), nil //nolint:gosec // range is checked
+	case TypeTimestamp64:
+		return time.UnixMicro(val.GetInt64Value()).UTC(), nil
+	case Ydb.Type_TZ_DATE, Ydb.Type_TZ_DATETIME, Ydb.Type_TZ_TIMESTAMP:
+		return decodeTz(val.GetTextValue(), id)
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %s is not a time type", ErrDecode, FormatType(typ))
+}
+
+func decodeTz(text string, id Ydb.Type_PrimitiveTypeId) (time.Time, error) {
+	idx := strings.LastIndex(text, tzSeparator)
+	if idx < 0 {
+		return time.Time{}, fmt.Errorf("%w: %s %q has no time zone", ErrDecode, primitiveNames[id], text)
+	}
+
+	loc, err := time.LoadLocation(text[idx+1:])
+	if err != nil {
+		return time.Time{}, errors.Join(ErrDecode, ErrTimeZone, err)
+	}
+
+	layout := tzTimeLayout // fractional seconds are accepted when parsing
+	if id == Ydb.Type_TZ_DATE {
+		layout = tzDateLayout
+	}
+
+	t, err := time.ParseInLocation(layout, text[:idx], loc)
+	if err != nil {
+		return time.Time{}, errors.Join(ErrDecode, err)
+	}
+
+	return t, nil
+}
+
+// DecodeDuration decodes value of Interval or Interval64 type.
+// Interval64 values which do not fit into time.Duration are reported as error.
+func DecodeDuration(typ *Ydb.Type, val *Ydb.Value) (time.Duration, error) {
+	if !IsDuration(typ.GetTypeId()) {
+		return 0, fmt.Errorf("%w: %s is not an interval type", ErrDecode, FormatType(typ))
+	}
+
+	usec := val.GetInt64Value()
+	if usec > int64(maxDuration/time.Microsecond) || usec < int64(minDuration/time.Microsecond) {
+		return 0, fmt.Errorf("%w: %s %d overflows time.Duration", ErrDecode, FormatType(typ), usec)
+	}
+
+	return time.Duration(usec) * time.Microsecond, nil
+}
@@ -0,0 +1,170 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestTemporal(t *testing.T) {
+	msk, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 9, 20, 12, 30, 15, 123456000, time.UTC)
+	before := time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name     string
+		build    func() (*Ydb.TypedValue, error)
+		wantType string
+		wantVal  *Ydb.Value
+		wantTime time.Time
+	}{
+		{
+			name:     "date",
+			build:    func() (*Ydb.TypedValue, error) { return Date(ts) },
+			wantType: "Date",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: 19986}},
+			wantTime: time.Date(2024, 9, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "date in location",
+			build:    func() (*Ydb.TypedValue, error) { return Date(time.Date(2024, 9, 20, 1, 0, 0, 0, msk)) },
+			wantType: "Date",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: 19986}},
+			wantTime: time.Date(2024, 9, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "date32 before epoch",
+			build:    func() (*Ydb.TypedValue, error) { return Date32(before) },
+			wantType: "Date32",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: -1}},
+			wantTime: time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "datetime",
+			build:    func() (*Ydb.TypedValue, error) { return Datetime(ts) },
+			wantType: "Datetime",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: uint32(ts.Unix())}},
+			wantTime: ts.Truncate(time.Second),
+		},
+		{
+			name:     "datetime64 before epoch",
+			build:    func() (*Ydb.TypedValue, error) { return Datetime64(before) },
+			wantType: "Datetime64",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -3600}},
+			wantTime: before,
+		},
+		{
+			name:     "timestamp",
+			build:    func() (*Ydb.TypedValue, error) { return Timestamp(ts) },
+			wantType: "Timestamp",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: uint64(ts.UnixMicro())}},
+			wantTime: ts,
+		},
+		{
+			name:     "timestamp64 before epoch",
+			build:    func() (*Ydb.TypedValue, error) { return Timestamp64(before) },
+			wantType: "Timestamp64",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -3600000000}},
+			wantTime: before,
+		},
+		{
+			name:     "tz date",
+			build:    func() (*Ydb.TypedValue, error) { return TzDate(time.Date(2024, 9, 20, 1, 0, 0, 0, msk)) },
+			wantType: "TzDate",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "2024-09-20,Europe/Moscow"}},
+			wantTime: time.Date(2024, 9, 20, 0, 0, 0, 0, msk),
+		},
+		{
+			name:     "tz datetime",
+			build:    func() (*Ydb.TypedValue, error) { return TzDatetime(ts.In(msk)) },
+			wantType: "TzDatetime",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "2024-09-20T15:30:15,Europe/Moscow"}},
+			wantTime: ts.Truncate(time.Second).In(msk),
+		},
+		{
+			name:     "tz timestamp",
+			build:    func() (*Ydb.TypedValue, error) { return TzTimestamp(ts.In(msk)) },
+			wantType: "TzTimestamp",
+			wantVal:  &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "2024-09-20T15:30:15.123456,Europe/Moscow"}},
+			wantTime: ts.In(msk),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tv, err := tt.build()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, FormatType(tv.Type))
+			assert.Equal(t, tt.wantVal, tv.Value)
+
+			decoded, err := DecodeTime(tv.Type, tv.Value)
+			require.NoError(t, err)
+			assert.True(t, tt.wantTime.Equal(decoded), "want %s, got %s", tt.wantTime, decoded)
+			assert.Equal(t, tt.wantTime.Location().String(), decoded.Location().String())
+		})
+	}
+}
+
+func TestTemporalRange(t *testing.T) {
+	before := time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)
+	after := time.Date(2106, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, build := range []func(time.Time) (*Ydb.TypedValue, error){Date, Datetime, Timestamp} {
+		_, err := build(before)
+		require.ErrorIs(t, err, ErrRange)
+		_, err = build(after)
+		require.ErrorIs(t, err, ErrRange)
+	}
+
+	_, err := Date32(time.Date(-200000, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.ErrorIs(t, err, ErrRange)
+
+	_, err = TzDatetime(time.Date(2024, 9, 20, 0, 0, 0, 0, time.Local))
+	require.ErrorIs(t, err, ErrTimeZone)
+
+	_, err = Interval(50000 * 24 * time.Hour)
+	require.ErrorIs(t, err, ErrRange)
+
+	_, err = DecodeTime(
+		&Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_TZ_DATE}},
+		&Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: "2024-09-20"}})
+	require.ErrorIs(t, err, ErrDecode)
+
+	_, err = DecodeTime(
+		&Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UINT64}},
+		&Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: 1}})
+	require.ErrorIs(t, err, ErrDecode)
+}
+
+func TestInterval(t *testing.T) {
+	tv, err := Interval(-90 * time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "Interval", FormatType(tv.Type))
+	assert.Equal(t, int64(-5400000000), tv.Value.GetInt64Value())
+
+	d, err := DecodeDuration(tv.Type, tv.Value)
+	require.NoError(t, err)
+	assert.Equal(t, -90*time.Minute, d)
+
+	tv = Interval64(minDuration)
+	assert.Equal(t, "Interval64", FormatType(tv.Type))
+
+	_, err = DecodeDuration(tv.Type, &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: -1 << 62}})
+	require.ErrorIs(t, err, ErrDecode)
+}
+
+func TestFromGoTemporalOverride(t *testing.T) {
+	type row struct {
+		Day     time.Time     `ydb:"day,type=Date32"`
+		Elapsed time.Duration `ydb:"elapsed,type=Interval64"`
+	}
+
+	tv, err := FromGo(row{Day: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), Elapsed: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "Struct<day:Date32,elapsed:Interval64>", FormatType(tv.Type))
+	assert.Equal(t, int32(-3653), tv.Value.Items[0].GetInt32Value())
+	assert.Equal(t, int64(1000000), tv.Value.Items[1].GetInt64Value())
+}
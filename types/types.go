@@ -58,9 +58,14 @@ func UTF8(val string) *Ydb.TypedValue {
 	}
 }
 
+// Text creates String value from string. String is a binary type, use UTF8 for text.
 func Text(val string) *Ydb.TypedValue {
+	return Bytes([]byte(val))
+}
+
+func Bytes(val []byte) *Ydb.TypedValue {
 	return &Ydb.TypedValue{
 		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_STRING}},
-		Value: &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: val}},
+		Value: &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: val}},
 	}
 }
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+const uuidLen = 36
+
+var (
+	ErrFormat = errors.New("invalid format")
+)
+
+// UUIDValue is UUID in RFC 4122 byte order, the same as in its text representation.
+// It is compatible with [16]byte based UUID types like github.com/google/uuid.UUID.
+//
+// UUIDValue implements ValueMarshaler, so it can be used as query argument.
+// Values of Uuid type can be scanned into UUIDValue.
+type UUIDValue [16]byte
+
+// UUID creates Uuid value from UUID bytes in RFC 4122 order.
+//
+// YDB keeps UUID as 128-bit integer with first three groups in little-endian order,
+// for example 00112233-4455-6677-8899-aabbccddeeff is stored as bytes
+// 33 22 11 00 55 44 77 66 88 99 aa bb cc dd ee ff.
+func UUID(v [16]byte) *Ydb.TypedValue {
+	lo, hi := uuidToInt128(v)
+
+	return &Ydb.TypedValue{
+		Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_UUID}},
+		Value: &Ydb.Value{Value: &Ydb.Value_Low_128{Low_128: lo}, High_128: hi},
+	}
+}
+
+// ParseUUID parses UUID in canonical form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func ParseUUID(s string) (UUIDValue, error) {
+	var u UUIDValue
+	if len(s) != uuidLen || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("%w: %q is not UUID", ErrFormat, s)
+	}
+
+	b := make([]byte, 0, len(u)*2)
+	b = append(b, s[:8]...)
+	b = append(b, s[9:13]...)
+	b = append(b, s[14:18]...)
+	b = append(b, s[19:23]...)
+	b = append(b, s[24:]...)
+	if _, err := hex.Decode(u[:], b); err != nil {
+		return u, fmt.Errorf("%w: %q is not UUID", ErrFormat, s)
+	}
+
+	return u, nil
+}
+
+// DecodeUUID decodes value of Uuid type.
+func DecodeUUID(typ *Ydb.Type, val *Ydb.Value) (UUIDValue, error) {
+	if typ.GetTypeId() != Ydb.Type_UUID {
+		return UUIDValue{}, fmt.Errorf("%w: %s is not Uuid", ErrDecode, FormatType(typ))
+	}
+
+	return uuidFromInt128(val.GetLow_128(), val.GetHigh_128()), nil
+}
+
+// MarshalYDB creates Uuid value.
+func (u UUIDValue) MarshalYDB() (*Ydb.TypedValue, error) {
+	return UUID(u), nil
+}
+
+// String returns canonical representation of u.
+func (u UUIDValue) String() string {
+	var b [uuidLen]byte
+	hex.Encode(b[:8], u[:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+
+	return string(b[:])
+}
+
+func uuidToInt128(u [16]byte) (uint64, uint64) {
+	var le [16]byte
+	copy(le[:], u[:])
+	swapUUIDGroups(&le)
+
+	return binary.LittleEndian.Uint64(le[:8]), binary.LittleEndian.Uint64(le[8:])
+}
+
+func uuidFromInt128(lo, hi uint64) UUIDValue {
+	var u UUIDValue
+	binary.LittleEndian.PutUint64(u[:8], lo)
+	binary.LittleEndian.PutUint64(u[8:], hi)
+	swapUUIDGroups((*[16]byte)(&u))
+
+	return u
+}
+
+// swapUUIDGroups reverses byte order of first three UUID groups.
+func swapUUIDGroups(b *[16]byte) {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUID(t *testing.T) {
+	u, err := ParseUUID("00112233-4455-6677-8899-aabbccddeeff")
+	require.NoError(t, err)
+	assert.Equal(t, UUIDValue{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77,
+		0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}, u)
+	assert.Equal(t, "00112233-4455-6677-8899-aabbccddeeff", u.String())
+
+	tv := UUID(u)
+	assert.Equal(t, "Uuid", FormatType(tv.Type))
+	assert.Equal(t, uint64(0x6677445500112233), tv.Value.GetLow_128())
+	assert.Equal(t, uint64(0xffeeddccbbaa9988), tv.Value.GetHigh_128())
+
+	decoded, err := DecodeUUID(tv.Type, tv.Value)
+	require.NoError(t, err)
+	assert.Equal(t, u, decoded)
+
+	_, err = DecodeUUID(UTF8("").Type, tv.Value)
+	require.ErrorIs(t, err, ErrDecode)
+
+	for _, s := range []string{"", "00112233445566778899aabbccddeeff", "0011223g-4455-6677-8899-aabbccddeeff"} {
+		_, err = ParseUUID(s)
+		require.ErrorIs(t, err, ErrFormat, s)
+	}
+}
+
+func TestUUID_FromGo(t *testing.T) {
+	type uuid [16]byte
+
+	want := UUID([16]byte{1, 2, 3})
+	for _, v := range []any{[16]byte{1, 2, 3}, uuid{1, 2, 3}, UUIDValue{1, 2, 3}} {
+		tv, err := FromGo(v)
+		require.NoError(t, err, "%T", v)
+		assert.Equal(t, want, tv, "%T", v)
+	}
+
+	params, err := ParamsFromStruct(struct {
+		ID string `ydb:"id,type=Uuid"`
+	}{ID: "01020300-0000-0000-0000-000000000000"})
+	require.NoError(t, err)
+	assert.Equal(t, want, params["$id"])
+}
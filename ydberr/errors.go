@@ -0,0 +1,237 @@
+// Package ydberr provides typed errors returned by YDB operations
+// and helpers to inspect them.
+package ydberr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// IssueCodeConstraintViolation is reported by YDB when operation
+	// violates table constraint, for example on duplicate primary key insert.
+	IssueCodeConstraintViolation = 2012
+)
+
+type (
+	// OperationError is returned when YDB completes operation with unsuccessful status.
+	OperationError struct {
+		// Endpoint is the address of YDB node which responded with error, if known.
+		Endpoint string
+
+		// Query is the text of the query which caused the error, if any.
+		// It is used to highlight issue positions.
+		Query string
+
+		// Issues is the issue tree which describes the error.
+		Issues []*Ydb_Issue.IssueMessage
+
+		// NodeID is the id of YDB node which responded with error, if known.
+		NodeID int64
+
+		Status Ydb.StatusIds_StatusCode
+	}
+
+	// TransportError is returned when operation failed on grpc level.
+	TransportError struct {
+		// Err is original grpc error.
+		Err error
+
+		// Endpoint is the address of YDB node, if known.
+		Endpoint string
+
+		Code codes.Code
+	}
+)
+
+func NewOperationError(code Ydb.StatusIds_StatusCode, issues []*Ydb_Issue.IssueMessage) *OperationError {
+	return &OperationError{
+		Status: code,
+		Issues: issues,
+	}
+}
+
+func (e *OperationError) Error() string {
+	var b strings.Builder
+	b.WriteString("operation failed with status ")
+	b.WriteString(e.Status.String())
+	writeOrigin(&b, e.Endpoint, e.NodeID)
+
+	for i, issue := range e.Issues {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(issue.GetMessage())
+	}
+
+	return b.String()
+}
+
+// Format implements fmt.Formatter. Verb %+v renders error message
+// followed by the issue tree, see FormatIssues.
+func (e *OperationError) Format(f fmt.State, verb rune) {
+	FormatError(f, verb, e, e.Issues, e.Query)
+}
+
+// RetryClass returns retry class of the error.
+func (e *OperationError) RetryClass() RetryClass {
+	return ClassifyStatus(e.Status)
+}
+
+// NewTransportError wraps grpc error with TransportError.
+// Errors which do not carry grpc status (like io.EOF) and nil are returned as is.
+func NewTransportError(err error, endpoint string) error {
+	if err == nil {
+		return nil
+	}
+
+	var trErr *TransportError
+	if errors.As(err, &trErr) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return &TransportError{
+		Err:      err,
+		Endpoint: endpoint,
+		Code:     st.Code(),
+	}
+}
+
+func (e *TransportError) Error() string {
+	var b strings.Builder
+	b.WriteString("transport error")
+	writeOrigin(&b, e.Endpoint, 0)
+	b.WriteString(": ")
+	b.WriteString(e.Err.Error())
+
+	return b.String()
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns grpc status of the original error.
+func (e *TransportError) GRPCStatus() *status.Status {
+	st, _ := status.FromError(e.Err)
+
+	return st
+}
+
+// Format implements fmt.Formatter. Verb %+v additionally renders
+// grpc status code and details of the original error.
+func (e *TransportError) Format(f fmt.State, verb rune) {
+	if verb != 'v' || !f.Flag('+') {
+		FormatError(f, verb, e, nil, "")
+		return
+	}
+
+	_, _ = io.WriteString(f, e.Error())
+	_, _ = fmt.Fprintf(f, "\ncode: %s", e.Code)
+	for _, detail := range e.GRPCStatus().Details() {
+		_, _ = fmt.Fprintf(f, "\ndetail: %v", detail)
+	}
+}
+
+// RetryClass returns retry class of the error.
+func (e *TransportError) RetryClass() RetryClass {
+	return ClassifyCode(e.Code)
+}
+
+// IsStatus reports whether err is OperationError with one of provided statuses.
+func IsStatus(err error, codes ...Ydb.StatusIds_StatusCode) bool {
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	for _, code := range codes {
+		if opErr.Status == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTransportCode reports whether err is transport error with one of provided grpc codes.
+func IsTransportCode(err error, codes ...codes.Code) bool {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+	code := grpcErr.GRPCStatus().Code()
+	for _, c := range codes {
+		if code == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasIssueCode reports whether err is OperationError
+// which has issue with provided code anywhere in its issue tree.
+func HasIssueCode(err error, code uint32) bool {
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+
+	return hasIssueCode(opErr.Issues, code)
+}
+
+func hasIssueCode(issues []*Ydb_Issue.IssueMessage, code uint32) bool {
+	for _, issue := range issues {
+		if issue.GetIssueCode() == code || hasIssueCode(issue.GetIssues(), code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsNotFound reports whether requested object does not exist.
+func IsNotFound(err error) bool {
+	return IsStatus(err, Ydb.StatusIds_NOT_FOUND) || IsTransportCode(err, codes.NotFound)
+}
+
+// IsConstraintViolation reports whether operation violated table constraint,
+// for example tried to insert row with existing primary key.
+func IsConstraintViolation(err error) bool {
+	return IsStatus(err, Ydb.StatusIds_PRECONDITION_FAILED) &&
+		HasIssueCode(err, IssueCodeConstraintViolation)
+}
+
+func writeOrigin(b *strings.Builder, endpoint string, nodeID int64) {
+	switch {
+	case endpoint != "" && nodeID != 0:
+		b.WriteString(" (node ")
+		b.WriteString(strconv.FormatInt(nodeID, 10))
+		b.WriteString(", endpoint ")
+		b.WriteString(endpoint)
+		b.WriteByte(')')
+	case nodeID != 0:
+		b.WriteString(" (node ")
+		b.WriteString(strconv.FormatInt(nodeID, 10))
+		b.WriteByte(')')
+	case endpoint != "":
+		b.WriteString(" (endpoint ")
+		b.WriteString(endpoint)
+		b.WriteByte(')')
+	}
+}
@@ -0,0 +1,58 @@
+package ydberr
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestOperationError(t *testing.T) {
+	opErr := NewOperationError(Ydb.StatusIds_PRECONDITION_FAILED, []*Ydb_Issue.IssueMessage{{
+		Message: "Execution failed",
+		Issues: []*Ydb_Issue.IssueMessage{{
+			Message:   "Conflict with existing key.",
+			IssueCode: IssueCodeConstraintViolation,
+		}},
+	}})
+	opErr.Endpoint = "localhost:2136"
+	opErr.NodeID = 1
+
+	err := fmt.Errorf("exec: %w", opErr)
+	assert.Equal(t, "exec: operation failed with status PRECONDITION_FAILED "+
+		"(node 1, endpoint localhost:2136): Execution failed", err.Error())
+	assert.True(t, IsConstraintViolation(err))
+	assert.True(t, IsStatus(err, Ydb.StatusIds_NOT_FOUND, Ydb.StatusIds_PRECONDITION_FAILED))
+	assert.False(t, IsNotFound(err))
+	assert.False(t, IsRetryable(err))
+
+	assert.False(t, IsConstraintViolation(NewOperationError(Ydb.StatusIds_PRECONDITION_FAILED, nil)))
+	assert.True(t, IsNotFound(errors.Join(errors.New("wrapped"), NewOperationError(Ydb.StatusIds_NOT_FOUND, nil))))
+}
+
+func TestTransportError(t *testing.T) {
+	assert.NoError(t, NewTransportError(nil, "localhost:2136"))
+	assert.Equal(t, io.EOF, NewTransportError(io.EOF, "localhost:2136"))
+
+	err := NewTransportError(status.Error(codes.NotFound, "no such method"), "localhost:2136")
+
+	var trErr *TransportError
+	require.ErrorAs(t, err, &trErr)
+	assert.Equal(t, codes.NotFound, trErr.Code)
+	assert.Equal(t, "localhost:2136", trErr.Endpoint)
+	assert.Equal(t, "transport error (endpoint localhost:2136): "+
+		"rpc error: code = NotFound desc = no such method", err.Error())
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.True(t, IsNotFound(err))
+	assert.True(t, IsTransportCode(err, codes.NotFound))
+
+	// already wrapped
+	assert.Same(t, trErr, NewTransportError(err, "other"))
+}
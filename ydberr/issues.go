@@ -0,0 +1,132 @@
+package ydberr
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+)
+
+const (
+	issueIndent = "  "
+)
+
+var severities = []string{"FATAL", "ERROR", "WARNING", "INFO"}
+
+// FormatIssues renders issue tree in human-readable form, one issue per line,
+// nested issues are indented. If query text is provided, issues with position
+// are followed by the corresponding query line with caret under the position.
+//
+//	ERROR: Type annotation [code 1030]
+//	  ERROR 1:8: Unknown name: $x [code 1030]
+//	    1 | SELECT $x;
+//	      |        ^
+func FormatIssues(issues []*Ydb_Issue.IssueMessage, query string) string {
+	var (
+		b     strings.Builder
+		lines []string
+	)
+	if query != "" {
+		lines = strings.Split(query, "\n")
+	}
+	writeIssues(&b, issues, lines, "")
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeIssues(b *strings.Builder, issues []*Ydb_Issue.IssueMessage, lines []string, indent string) {
+	for _, issue := range issues {
+		b.WriteString(indent)
+		b.WriteString(severityName(issue.GetSeverity()))
+
+		pos := issue.GetPosition()
+		if pos.GetRow() > 0 {
+			b.WriteByte(' ')
+			if pos.GetFile() != "" {
+				b.WriteString(pos.GetFile())
+				b.WriteByte(':')
+			}
+			b.WriteString(strconv.FormatUint(uint64(pos.GetRow()), 10))
+			b.WriteByte(':')
+			b.WriteString(strconv.FormatUint(uint64(pos.GetColumn()), 10))
+		}
+
+		b.WriteString(": ")
+		b.WriteString(issue.GetMessage())
+		if issue.GetIssueCode() != 0 {
+			b.WriteString(" [code ")
+			b.WriteString(strconv.FormatUint(uint64(issue.GetIssueCode()), 10))
+			b.WriteByte(']')
+		}
+		b.WriteByte('\n')
+
+		writeSnippet(b, issue, lines, indent+issueIndent)
+		writeIssues(b, issue.GetIssues(), lines, indent+issueIndent)
+	}
+}
+
+// writeSnippet writes query line which issue position points to with caret under the position.
+func writeSnippet(b *strings.Builder, issue *Ydb_Issue.IssueMessage, lines []string, indent string) {
+	pos := issue.GetPosition()
+	row := int(pos.GetRow())
+	if row <= 0 || row > len(lines) || pos.GetFile() != "" {
+		return
+	}
+
+	line := []rune(strings.TrimRight(lines[row-1], "\r"))
+	num := strconv.Itoa(row)
+
+	b.WriteString(indent)
+	b.WriteString(num)
+	b.WriteString(" | ")
+	b.WriteString(string(line))
+	b.WriteByte('\n')
+
+	b.WriteString(indent)
+	b.WriteString(strings.Repeat(" ", len(num)))
+	b.WriteString(" | ")
+
+	col := max(int(pos.GetColumn()), 1)
+	for i := 0; i < col-1 && i < len(line); i++ {
+		// keep tabs to align caret
+		if line[i] == '\t' {
+			b.WriteByte('\t')
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	b.WriteByte('^')
+
+	if end := issue.GetEndPosition(); int(end.GetRow()) == row && int(end.GetColumn()) > col+1 {
+		b.WriteString(strings.Repeat("~", int(end.GetColumn())-col-1))
+	}
+	b.WriteByte('\n')
+}
+
+func severityName(severity uint32) string {
+	if int(severity) < len(severities) {
+		return severities[severity]
+	}
+
+	return "SEVERITY(" + strconv.FormatUint(uint64(severity), 10) + ")"
+}
+
+// FormatError implements fmt.Formatter for error types which hold issues,
+// like query result errors. Verb %+v renders error message followed by the issue tree,
+// see FormatIssues.
+func FormatError(f fmt.State, verb rune, err error, issues []*Ydb_Issue.IssueMessage, query string) {
+	switch verb {
+	case 'v':
+		_, _ = io.WriteString(f, err.Error())
+		if f.Flag('+') && len(issues) > 0 {
+			_, _ = io.WriteString(f, "\n")
+			_, _ = io.WriteString(f, FormatIssues(issues, query))
+		}
+	case 's':
+		_, _ = io.WriteString(f, err.Error())
+	case 'q':
+		_, _ = fmt.Fprintf(f, "%q", err.Error())
+	}
+}
@@ -0,0 +1,66 @@
+package ydberr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+)
+
+func testIssues() []*Ydb_Issue.IssueMessage {
+	return []*Ydb_Issue.IssueMessage{{
+		Message:   "Type annotation",
+		IssueCode: 1030,
+		Severity:  1,
+		Issues: []*Ydb_Issue.IssueMessage{
+			{
+				Position:    &Ydb_Issue.IssueMessage_Position{Row: 2, Column: 8},
+				EndPosition: &Ydb_Issue.IssueMessage_Position{Row: 2, Column: 11},
+				Message:     "Unknown name: $id",
+				IssueCode:   1030,
+				Severity:    1,
+			},
+			{
+				Message:  "Deprecated pragma",
+				Severity: 2,
+			},
+		},
+	}}
+}
+
+func TestFormatIssues(t *testing.T) {
+	query := "SELECT *\n\tWHERE $id = 1;"
+
+	assert.Equal(t, `ERROR: Type annotation [code 1030]
+  ERROR 2:8: Unknown name: $id [code 1030]
+    2 | 	WHERE $id = 1;
+      | 	      ^~~
+  WARNING: Deprecated pragma`, FormatIssues(testIssues(), query))
+
+	// without query text
+	assert.Equal(t, `ERROR: Type annotation [code 1030]
+  ERROR 2:8: Unknown name: $id [code 1030]
+  WARNING: Deprecated pragma`, FormatIssues(testIssues(), ""))
+
+	// position out of query
+	assert.Equal(t, "FATAL 5:1: oops", FormatIssues([]*Ydb_Issue.IssueMessage{{
+		Position: &Ydb_Issue.IssueMessage_Position{Row: 5, Column: 1},
+		Message:  "oops",
+	}}, query))
+}
+
+func TestOperationError_Format(t *testing.T) {
+	err := NewOperationError(Ydb.StatusIds_GENERIC_ERROR, testIssues())
+	err.Query = "SELECT *\n\tWHERE $id = 1;"
+
+	assert.Equal(t, "operation failed with status GENERIC_ERROR: Type annotation", fmt.Sprintf("%v", err))
+	assert.Equal(t, "operation failed with status GENERIC_ERROR: Type annotation", fmt.Sprintf("%s", err))
+	assert.Equal(t, `operation failed with status GENERIC_ERROR: Type annotation
+ERROR: Type annotation [code 1030]
+  ERROR 2:8: Unknown name: $id [code 1030]
+    2 | 	WHERE $id = 1;
+      | 	      ^~~
+  WARNING: Deprecated pragma`, fmt.Sprintf("%+v", err))
+}
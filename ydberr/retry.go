@@ -0,0 +1,123 @@
+package ydberr
+
+import (
+	"errors"
+
+	localErrs "github.com/adwski/ydb-go-query/internal/errors"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RetryNever means error cannot be retried.
+	RetryNever RetryMode = iota
+	// RetryIdempotent means error can be retried only if operation is idempotent,
+	// because it is unknown whether operation was applied or not.
+	RetryIdempotent
+	// RetryAlways means operation was definitely not applied and can be safely retried.
+	RetryAlways
+)
+
+const (
+	BackoffNone Backoff = iota
+	BackoffFast
+	BackoffSlow
+)
+
+type (
+	RetryMode uint8
+	Backoff   uint8
+
+	// RetryClass describes how particular error should be retried.
+	RetryClass struct {
+		Mode    RetryMode
+		Backoff Backoff
+
+		// DeleteSession indicates that session which produced
+		// the error cannot be used anymore.
+		DeleteSession bool
+	}
+)
+
+// Retryable reports whether error of this class can be retried
+// for operation with provided idempotency.
+func (c RetryClass) Retryable(idempotent bool) bool {
+	switch c.Mode {
+	case RetryAlways:
+		return true
+	case RetryIdempotent:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// ClassifyStatus returns retry class for YDB operation status.
+func ClassifyStatus(code Ydb.StatusIds_StatusCode) RetryClass {
+	switch code { //nolint:exhaustive // other statuses are not retryable
+	case Ydb.StatusIds_ABORTED,
+		Ydb.StatusIds_UNAVAILABLE:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffFast}
+	case Ydb.StatusIds_OVERLOADED:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffSlow}
+	case Ydb.StatusIds_BAD_SESSION:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffNone, DeleteSession: true}
+	case Ydb.StatusIds_SESSION_BUSY:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffFast, DeleteSession: true}
+	case Ydb.StatusIds_SESSION_EXPIRED:
+		return RetryClass{Mode: RetryIdempotent, Backoff: BackoffNone, DeleteSession: true}
+	case Ydb.StatusIds_UNDETERMINED,
+		Ydb.StatusIds_CANCELLED:
+		return RetryClass{Mode: RetryIdempotent, Backoff: BackoffFast}
+	default:
+		return RetryClass{}
+	}
+}
+
+// ClassifyCode returns retry class for grpc transport error code.
+func ClassifyCode(code codes.Code) RetryClass {
+	switch code { //nolint:exhaustive // other codes are not retryable
+	case codes.ResourceExhausted:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffSlow}
+	case codes.Aborted:
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffFast}
+	case codes.Unavailable,
+		codes.Internal,
+		codes.Canceled,
+		codes.DeadlineExceeded:
+		return RetryClass{Mode: RetryIdempotent, Backoff: BackoffFast, DeleteSession: true}
+	default:
+		return RetryClass{}
+	}
+}
+
+// Classify returns retry class for arbitrary error.
+// Unknown errors are not retryable.
+func Classify(err error) RetryClass {
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		return opErr.RetryClass()
+	}
+
+	if errors.Is(err, localErrs.LocalFailureError{}) {
+		// request was not sent
+		return RetryClass{Mode: RetryAlways, Backoff: BackoffFast}
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return ClassifyCode(grpcErr.GRPCStatus().Code())
+	}
+
+	return RetryClass{}
+}
+
+// IsRetryable reports whether operation which returned err
+// was not applied and can be safely repeated.
+// Errors which are retryable only for idempotent operations
+// can be detected with Classify(err).Retryable(true).
+func IsRetryable(err error) bool {
+	return Classify(err).Mode == RetryAlways
+}
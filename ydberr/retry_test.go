@@ -0,0 +1,97 @@
+package ydberr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	localErrs "github.com/adwski/ydb-go-query/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		want          RetryClass
+		idempotent    bool
+		nonIdempotent bool
+	}{
+		{
+			name:          "aborted",
+			err:           errors.Join(errors.New("result error"), NewOperationError(Ydb.StatusIds_ABORTED, nil)),
+			want:          RetryClass{Mode: RetryAlways, Backoff: BackoffFast},
+			idempotent:    true,
+			nonIdempotent: true,
+		},
+		{
+			name:          "overloaded",
+			err:           &OperationError{Status: Ydb.StatusIds_OVERLOADED},
+			want:          RetryClass{Mode: RetryAlways, Backoff: BackoffSlow},
+			idempotent:    true,
+			nonIdempotent: true,
+		},
+		{
+			name:          "bad session",
+			err:           fmt.Errorf("wrapped: %w", &OperationError{Status: Ydb.StatusIds_BAD_SESSION}),
+			want:          RetryClass{Mode: RetryAlways, Backoff: BackoffNone, DeleteSession: true},
+			idempotent:    true,
+			nonIdempotent: true,
+		},
+		{
+			name:       "undetermined",
+			err:        &OperationError{Status: Ydb.StatusIds_UNDETERMINED},
+			want:       RetryClass{Mode: RetryIdempotent, Backoff: BackoffFast},
+			idempotent: true,
+		},
+		{
+			name: "scheme error",
+			err:  &OperationError{Status: Ydb.StatusIds_SCHEME_ERROR},
+			want: RetryClass{},
+		},
+		{
+			name:       "transport unavailable",
+			err:        errors.Join(errors.New("exec failed"), status.Error(codes.Unavailable, "unavailable")),
+			want:       RetryClass{Mode: RetryIdempotent, Backoff: BackoffFast, DeleteSession: true},
+			idempotent: true,
+		},
+		{
+			name:          "transport resource exhausted",
+			err:           status.Error(codes.ResourceExhausted, "exhausted"),
+			want:          RetryClass{Mode: RetryAlways, Backoff: BackoffSlow},
+			idempotent:    true,
+			nonIdempotent: true,
+		},
+		{
+			name:       "wrapped transport error",
+			err:        NewTransportError(status.Error(codes.DeadlineExceeded, "deadline"), "localhost:2136"),
+			want:       RetryClass{Mode: RetryIdempotent, Backoff: BackoffFast, DeleteSession: true},
+			idempotent: true,
+		},
+		{
+			name:          "local failure",
+			err:           errors.Join(localErrs.LocalFailureError{}, errors.New("no connections")),
+			want:          RetryClass{Mode: RetryAlways, Backoff: BackoffFast},
+			idempotent:    true,
+			nonIdempotent: true,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("unknown"),
+			want: RetryClass{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			class := Classify(tt.err)
+			assert.Equal(t, tt.want, class)
+			assert.Equal(t, tt.idempotent, class.Retryable(true))
+			assert.Equal(t, tt.nonIdempotent, class.Retryable(false))
+			assert.Equal(t, tt.nonIdempotent, IsRetryable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package ydbsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+var (
+	ErrTxActive  = errors.New("transaction is already active")
+	ErrIsolation = errors.New("unsupported isolation level")
+)
+
+type (
+	conn struct {
+		qc *query.Ctx
+		tx *tx
+
+		// closeClient closes client opened for connection by Driver.Open.
+		closeClient func()
+
+		closed bool
+	}
+
+	stmt struct {
+		conn  *conn
+		query string
+	}
+
+	tx struct {
+		// ctx is a context of BeginTx, database/sql rolls back
+		// transaction when it is done.
+		ctx  context.Context //nolint:containedctx // tx context
+		conn *conn
+		tx   *query.Transaction
+	}
+
+	// txMode is YDB transaction mode corresponding to database/sql tx options.
+	txMode int
+)
+
+const (
+	txSerializableReadWrite txMode = iota
+	txSnapshotReadOnly
+	txOnlineReadOnly
+	txOnlineReadOnlyInconsistent
+)
+
+var (
+	_ driver.Conn               = (*conn)(nil)
+	_ driver.ConnBeginTx        = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.NamedValueChecker  = (*conn)(nil)
+	_ driver.Pinger             = (*conn)(nil)
+
+	_ driver.StmtExecContext  = (*stmt)(nil)
+	_ driver.StmtQueryContext = (*stmt)(nil)
+)
+
+func (c *conn) Prepare(queryContent string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), queryContent)
+}
+
+// PrepareContext returns statement which holds query text.
+// Queries are compiled on YDB side and cached there, so no round trip is made.
+func (c *conn) PrepareContext(_ context.Context, queryContent string) (driver.Stmt, error) {
+	if c.closed {
+		return nil, driver.ErrBadConn
+	}
+
+	return &stmt{conn: c, query: queryContent}, nil
+}
+
+func (c *conn) Close() error {
+	if c.closed {
+		return nil
+	}
+	if c.tx != nil {
+		_ = c.tx.Rollback()
+	}
+	c.closed = true
+
+	if c.closeClient != nil {
+		c.closeClient()
+	}
+
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+// BeginTx starts transaction. Isolation levels are mapped to YDB transaction modes:
+//   - Default, Serializable, Linearizable: SerializableReadWrite, or SnapshotReadOnly if read-only
+//   - Snapshot, RepeatableRead (read-only only): SnapshotReadOnly
+//   - ReadCommitted (read-only only): OnlineReadOnly
+//   - ReadUncommitted (read-only only): OnlineReadOnly with inconsistent reads
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if c.closed {
+		return nil, driver.ErrBadConn
+	}
+	if c.tx != nil {
+		return nil, ErrTxActive
+	}
+
+	mode, err := txModeOf(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	qc := c.qc
+	switch mode {
+	case txSerializableReadWrite:
+		qc = qc.SerializableReadWrite()
+	case txSnapshotReadOnly:
+		qc = qc.SnapshotReadOnly()
+	case txOnlineReadOnly:
+		qc = qc.OnlineReadOnly()
+	case txOnlineReadOnlyInconsistent:
+		qc = qc.OnlineReadOnlyInconsistent()
+	}
+
+	qtx, err := qc.Tx(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // unnecessary
+	}
+
+	c.tx = &tx{ctx: ctx, conn: c, tx: qtx}
+
+	return c.tx, nil
+}
+
+func txModeOf(opts driver.TxOptions) (txMode, error) {
+	switch sql.IsolationLevel(opts.Isolation) { //nolint:exhaustive // other levels are not supported
+	case sql.LevelDefault, sql.LevelSerializable, sql.LevelLinearizable:
+		if opts.ReadOnly {
+			return txSnapshotReadOnly, nil
+		}
+
+		return txSerializableReadWrite, nil
+	case sql.LevelSnapshot, sql.LevelRepeatableRead:
+		if opts.ReadOnly {
+			return txSnapshotReadOnly, nil
+		}
+	case sql.LevelReadCommitted:
+		if opts.ReadOnly {
+			return txOnlineReadOnly, nil
+		}
+	case sql.LevelReadUncommitted:
+		if opts.ReadOnly {
+			return txOnlineReadOnlyInconsistent, nil
+		}
+	}
+
+	level := sql.IsolationLevel(opts.Isolation).String()
+	if !opts.ReadOnly {
+		level += " read-write"
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrIsolation, level)
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if c.closed {
+		return driver.ErrBadConn
+	}
+
+	return resultErr(c.qc.Exec(ctx, `SELECT 1`))
+}
+
+// CheckNamedValue converts argument to YDB value.
+func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
+	val, err := types.FromGo(nv.Value)
+	if err != nil {
+		return fmt.Errorf("argument %s: %w", paramName(nv), err)
+	}
+	nv.Value = val
+
+	return nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, queryContent string, args []driver.NamedValue) (driver.Result, error) {
+	if c.closed {
+		return nil, driver.ErrBadConn
+	}
+
+	params, err := queryParams(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tx != nil {
+		if err = resultErr(c.tx.tx.Query(queryContent).Params(params).Exec(ctx)); err != nil {
+			return nil, err
+		}
+
+		return driver.ResultNoRows, nil
+	}
+
+	if err = resultErr(c.qc.Query(queryContent).Params(params).Exec(ctx)); err != nil {
+		return nil, err
+	}
+
+	return driver.ResultNoRows, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, queryContent string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.closed {
+		return nil, driver.ErrBadConn
+	}
+
+	params, err := queryParams(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var stream *query.ResultStream
+	if c.tx != nil {
+		stream, err = c.tx.tx.Query(queryContent).Params(params).Stream(ctx)
+	} else {
+		stream, err = c.qc.Query(queryContent).Params(params).Stream(ctx)
+	}
+	if err != nil {
+		return nil, err //nolint:wrapcheck // unnecessary
+	}
+
+	return newRows(stream)
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1, since arguments count is not known without query compilation.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func (t *tx) Commit() error {
+	t.conn.tx = nil
+
+	if err := t.tx.Commit(t.ctx); err != nil {
+		// database/sql does not roll back after failed commit,
+		// rollback releases transaction session
+		_ = t.tx.Rollback(context.WithoutCancel(t.ctx))
+
+		return err //nolint:wrapcheck // unnecessary
+	}
+
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.conn.tx = nil
+
+	return t.tx.Rollback(context.WithoutCancel(t.ctx)) //nolint:wrapcheck // unnecessary
+}
+
+// queryParams returns query parameters from arguments.
+// Arguments are usually already converted by CheckNamedValue.
+func queryParams(args []driver.NamedValue) (map[string]*Ydb.TypedValue, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	params := make(map[string]*Ydb.TypedValue, len(args))
+	for _, arg := range args {
+		val, err := types.FromGo(arg.Value)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", paramName(&arg), err)
+		}
+		params[paramName(&arg)] = val
+	}
+
+	return params, nil
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, 0, len(args))
+	for i, arg := range args {
+		named = append(named, driver.NamedValue{Ordinal: i + 1, Value: arg})
+	}
+
+	return named
+}
+
+// paramName returns YDB parameter name of argument.
+func paramName(nv *driver.NamedValue) string {
+	if nv.Name == "" {
+		return fmt.Sprintf("$p%d", nv.Ordinal)
+	}
+	if nv.Name[0] == '$' {
+		return nv.Name
+	}
+
+	return "$" + nv.Name
+}
+
+// resultErr returns query error. Warnings do not fail the query.
+func resultErr(res *query.Result, err error) error {
+	if err != nil {
+		return err //nolint:wrapcheck // unnecessary
+	}
+	if err = res.Err(); err != nil && !errors.Is(err, query.ErrIssues) {
+		return err //nolint:wrapcheck // unnecessary
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+// Package ydbsql provides database/sql driver backed by YDB query service.
+//
+// Driver is registered with name "ydb" and accepts DSN in form of
+//
+//	ydb://[user:password@]host:port[,host:port...]/database[?options]
+//
+// Supported options are:
+//   - tls=true: use TLS transport, 'grpcs' scheme has the same effect
+//   - yc_key_file=path: authenticate with Yandex Cloud IAM key file
+//   - query_timeout=duration: query timeout
+//   - pool_size=n: session pool size
+//
+// Already opened client can be used with sql.OpenDB(ydbsql.NewConnector(client)).
+//
+// Query arguments are converted to YDB values, unnamed arguments are passed
+// as $p1, $p2, ... parameters and arguments created with sql.Named("name", v) as $name.
+// Missing DECLARE statements are generated automatically.
+package ydbsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	ydb "github.com/adwski/ydb-go-query"
+	"github.com/adwski/ydb-go-query/query"
+)
+
+const (
+	DriverName = "ydb"
+)
+
+var (
+	ErrDSN = errors.New("invalid dsn")
+)
+
+type (
+	// Driver implements driver.Driver and driver.DriverContext.
+	Driver struct{}
+
+	// Connector creates connections which share query context of YDB client.
+	Connector struct {
+		drv    *Driver
+		client *ydb.Client
+		qc     *query.Ctx
+
+		// owned indicates that client was opened by connector
+		// and must be closed with it.
+		owned bool
+	}
+
+	dsnConfig struct {
+		cfg  ydb.Config
+		opts []ydb.Option
+	}
+)
+
+//nolint:gochecknoinits // driver registration
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// NewConnector creates connector which uses already opened client.
+// Client is not closed when sql.DB is closed.
+func NewConnector(client *ydb.Client) *Connector {
+	return &Connector{
+		drv:    &Driver{},
+		client: client,
+		qc:     client.QueryCtx().AutoDeclare(),
+	}
+}
+
+// Open opens new client for every call, use sql.Open to share client between connections.
+// Client is closed together with returned connection.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.openConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{qc: c.qc, closeClient: c.client.Close}, nil
+}
+
+// OpenConnector parses dsn and opens YDB client.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	return d.openConnector(dsn)
+}
+
+func (d *Driver) openConnector(dsn string) (*Connector, error) {
+	dc, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// client lifetime is not bound to any particular context
+	client, err := ydb.Open(context.Background(), dc.cfg, dc.opts...)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // unnecessary
+	}
+
+	c := NewConnector(client)
+	c.drv = d
+	c.owned = true
+
+	return c, nil
+}
+
+// Connect returns new connection. Connections do not hold YDB sessions,
+// sessions are acquired from client session pool for every query or transaction.
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{qc: c.qc}, nil
+}
+
+func (c *Connector) Driver() driver.Driver {
+	return c.drv
+}
+
+// Close closes client if it was opened by connector. It is called by sql.DB.Close().
+func (c *Connector) Close() error {
+	if c.owned {
+		c.client.Close()
+	}
+
+	return nil
+}
+
+func parseDSN(dsn string) (*dsnConfig, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, errors.Join(ErrDSN, err)
+	}
+
+	var dc dsnConfig
+
+	switch u.Scheme {
+	case "ydb", "grpc":
+	case "grpcs":
+		dc.opts = append(dc.opts, ydb.WithTransportTLS())
+	default:
+		return nil, errors.Join(ErrDSN, errors.New("unsupported scheme: "+u.Scheme))
+	}
+
+	if u.Host == "" {
+		return nil, errors.Join(ErrDSN, errors.New("host is empty"))
+	}
+	dc.cfg.InitialNodes = strings.Split(u.Host, ",")
+	dc.cfg.DB = u.Path
+
+	params := u.Query()
+
+	if tls := params.Get("tls"); tls != "" {
+		enabled, errB := strconv.ParseBool(tls)
+		if errB != nil {
+			return nil, errors.Join(ErrDSN, errB)
+		}
+		if enabled && u.Scheme != "grpcs" {
+			dc.opts = append(dc.opts, ydb.WithTransportTLS())
+		}
+	}
+
+	if timeout := params.Get("query_timeout"); timeout != "" {
+		d, errD := time.ParseDuration(timeout)
+		if errD != nil {
+			return nil, errors.Join(ErrDSN, errD)
+		}
+		dc.opts = append(dc.opts, ydb.WithQueryTimeout(d))
+	}
+
+	if size := params.Get("pool_size"); size != "" {
+		n, errN := strconv.ParseUint(size, 10, 32)
+		if errN != nil {
+			return nil, errors.Join(ErrDSN, errN)
+		}
+		dc.opts = append(dc.opts, ydb.WithSessionPoolSize(uint(n)))
+	}
+
+	// auth options must follow transport security options
+	switch {
+	case params.Get("yc_key_file") != "":
+		dc.opts = append(dc.opts, ydb.WithYCAuthFile(params.Get("yc_key_file")))
+	case u.User != nil:
+		password, _ := u.User.Password()
+		dc.opts = append(dc.opts, ydb.WithUserPass(u.User.Username(), password))
+	}
+
+	return &dc, nil
+}
@@ -0,0 +1,283 @@
+package ydbsql
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/adwski/ydb-go-query/internal/jsonvalue"
+	"github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+type (
+	// rows reads result sets from result stream part by part.
+	rows struct {
+		stream *query.ResultStream
+
+		next func() (*query.ResultPart, error, bool)
+		stop func()
+
+		// part is a part of current result set which is being read
+		part *query.ResultPart
+		// pending is a first part of next result set
+		pending *query.ResultPart
+
+		cols []*Ydb.Column
+
+		// row is an index of next row in part
+		row int
+	}
+)
+
+var (
+	ErrOverflow = errors.New("value overflows int64")
+)
+
+var (
+	typeAny = reflect.TypeFor[any]()
+)
+
+var (
+	_ driver.Rows                           = (*rows)(nil)
+	_ driver.RowsNextResultSet              = (*rows)(nil)
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+)
+
+func newRows(stream *query.ResultStream) (*rows, error) {
+	next, stop := iter.Pull2(stream.Parts())
+	r := &rows{
+		stream: stream,
+		next:   next,
+		stop:   stop,
+	}
+
+	// first part is needed to know columns
+	part, err := r.pull()
+	if err != nil {
+		r.stop()
+		stream.Close()
+
+		return nil, err
+	}
+	r.setPart(part)
+
+	return r, nil
+}
+
+// pull returns next part of stream, nil part means stream is finished.
+func (r *rows) pull() (*query.ResultPart, error) {
+	part, err, ok := r.next()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, streamErr(r.stream)
+	}
+
+	return part, nil
+}
+
+func (r *rows) setPart(part *query.ResultPart) {
+	r.part = part
+	r.row = 0
+	if part != nil && len(part.Cols) > 0 {
+		r.cols = part.Cols
+	}
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, 0, len(r.cols))
+	for _, col := range r.cols {
+		names = append(names, col.Name)
+	}
+
+	return names
+}
+
+func (r *rows) Close() error {
+	r.stop()
+	r.stream.Close()
+
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	for r.part != nil && r.row == len(r.part.ResultSet.Rows) {
+		part, err := r.pull()
+		if err != nil {
+			return err
+		}
+		if part != nil && part.Index != r.part.Index {
+			// current result set is finished
+			r.pending = part
+			r.part = nil
+
+			break
+		}
+		r.setPart(part)
+	}
+
+	if r.part == nil {
+		return io.EOF
+	}
+
+	ptrs := make([]any, len(dest))
+	for i := range dest {
+		ptrs[i] = &dest[i]
+	}
+
+	value := r.part.ResultSet.Rows[r.row]
+	r.row++
+
+	if err := query.NewRow(r.cols, value).Scan(ptrs...); err != nil {
+		return err //nolint:wrapcheck // unnecessary
+	}
+
+	for i := range dest {
+		v, err := driverValue(dest[i])
+		if err != nil {
+			return fmt.Errorf("column %q: %w", r.cols[i].Name, err)
+		}
+		dest[i] = v
+	}
+
+	return nil
+}
+
+// driverValue converts value scanned into any to one of driver.Value types:
+// unsigned integers are converted to int64, durations to int64 nanoseconds,
+// and containers to JSON encoded the same way as with query.ResultSet.WriteJSONLines.
+func driverValue(v any) (driver.Value, error) {
+	switch tv := v.(type) {
+	case nil, bool, int64, float64, string, []byte, time.Time:
+		return v, nil
+	case uint64:
+		if tv > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d", ErrOverflow, tv)
+		}
+		return int64(tv), nil
+	case time.Duration:
+		return int64(tv), nil
+	}
+
+	b, err := jsonvalue.Marshal(v)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // wrapped by caller
+	}
+
+	return b, nil
+}
+
+// HasNextResultSet skips remaining rows of current result set
+// and reports whether next result set is available.
+func (r *rows) HasNextResultSet() bool {
+	for r.pending == nil && r.part != nil {
+		part, err := r.pull()
+		if err != nil || part == nil {
+			r.part = nil
+
+			return false
+		}
+		if part.Index != r.part.Index {
+			r.pending = part
+			r.part = nil
+		} else {
+			r.setPart(part)
+		}
+	}
+
+	return r.pending != nil
+}
+
+func (r *rows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+
+	r.setPart(r.pending)
+	r.pending = nil
+
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName returns YQL type name of column without Optional.
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return types.FormatType(itemType(r.cols[index].Type))
+}
+
+func (r *rows) ColumnTypeNullable(index int) (bool, bool) {
+	_, nullable := r.cols[index].Type.Type.(*Ydb.Type_OptionalType)
+
+	return nullable, true
+}
+
+// ColumnTypeScanType returns Go type of column values.
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return scanType(itemType(r.cols[index].Type))
+}
+
+func itemType(typ *Ydb.Type) *Ydb.Type {
+	if opt, ok := typ.Type.(*Ydb.Type_OptionalType); ok {
+		return opt.OptionalType.Item
+	}
+
+	return typ
+}
+
+// streamErr returns query error of finished stream. Warnings do not fail the query.
+func streamErr(stream *query.ResultStream) error {
+	if err := stream.Err(); err != nil && !errors.Is(err, query.ErrIssues) {
+		return err //nolint:wrapcheck // unnecessary
+	}
+
+	return nil
+}
+
+// scanType returns Go type of values which are returned for YDB type.
+func scanType(typ *Ydb.Type) reflect.Type {
+	switch t := typ.Type.(type) {
+	case *Ydb.Type_DecimalType:
+		return reflect.TypeFor[string]()
+	case *Ydb.Type_TaggedType:
+		return scanType(t.TaggedType.GetType())
+	case *Ydb.Type_ListType, *Ydb.Type_TupleType, *Ydb.Type_StructType, *Ydb.Type_DictType,
+		*Ydb.Type_VariantType, *Ydb.Type_EmptyListType, *Ydb.Type_EmptyDictType:
+		// JSON
+		return reflect.TypeFor[[]byte]()
+	}
+
+	id, ok := typ.Type.(*Ydb.Type_TypeId)
+	if !ok {
+		return typeAny
+	}
+	if types.IsTime(id.TypeId) {
+		return reflect.TypeFor[time.Time]()
+	}
+
+	switch id.TypeId { //nolint:exhaustive // other types are scanned as any
+	case Ydb.Type_BOOL:
+		return reflect.TypeFor[bool]()
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64,
+		Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64,
+		Ydb.Type_INTERVAL, types.TypeInterval64:
+		return reflect.TypeFor[int64]()
+	case Ydb.Type_FLOAT, Ydb.Type_DOUBLE:
+		return reflect.TypeFor[float64]()
+	case Ydb.Type_UTF8, Ydb.Type_JSON, Ydb.Type_JSON_DOCUMENT, Ydb.Type_DYNUMBER, Ydb.Type_UUID:
+		return reflect.TypeFor[string]()
+	case Ydb.Type_STRING, Ydb.Type_YSON:
+		return reflect.TypeFor[[]byte]()
+	}
+
+	return typeAny
+}
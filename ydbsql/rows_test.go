@@ -0,0 +1,97 @@
+package ydbsql
+
+import (
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/adwski/ydb-go-query/query"
+	"github.com/adwski/ydb-go-query/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+func TestScanType(t *testing.T) {
+	interval, err := types.Interval(time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, reflect.TypeFor[int64](), scanType(types.Int32(1).Type))
+	assert.Equal(t, reflect.TypeFor[int64](), scanType(types.Uint64(1).Type))
+	assert.Equal(t, reflect.TypeFor[int64](), scanType(interval.Type))
+	assert.Equal(t, reflect.TypeFor[string](), scanType(types.UTF8("").Type))
+	assert.Equal(t, reflect.TypeFor[[]byte](), scanType(&Ydb.Type{
+		Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{Item: types.UTF8("").Type}},
+	}))
+	assert.Equal(t, reflect.TypeFor[any](), scanType(&Ydb.Type{Type: &Ydb.Type_NullType{}}))
+}
+
+// testRows returns rows reading single part with provided values.
+func testRows(values ...*Ydb.TypedValue) *rows {
+	cols := make([]*Ydb.Column, 0, len(values))
+	row := &Ydb.Value{}
+	for i, v := range values {
+		cols = append(cols, &Ydb.Column{Name: string(rune('a' + i)), Type: v.Type})
+		row.Items = append(row.Items, v.Value)
+	}
+
+	return &rows{
+		part: &query.ResultPart{ResultSet: &Ydb.ResultSet{Columns: cols, Rows: []*Ydb.Value{row}}, Cols: cols},
+		cols: cols,
+	}
+}
+
+func TestRows_Next(t *testing.T) {
+	ts := time.Date(2024, 9, 20, 12, 0, 0, 0, time.UTC)
+	timestamp, err := types.Timestamp(ts)
+	require.NoError(t, err)
+	interval, err := types.Interval(time.Second)
+	require.NoError(t, err)
+	list, err := types.List(types.Uint64(1), types.Uint64(2))
+	require.NoError(t, err)
+	dict, err := types.Dict(types.Pair(types.UTF8("k"), types.Bytes([]byte("v"))))
+	require.NoError(t, err)
+
+	r := testRows(
+		types.Bool(true),
+		types.Int32(-1),
+		types.Uint64(math.MaxInt64),
+		types.Double(1.5),
+		types.UTF8("text"),
+		types.Bytes([]byte("bytes")),
+		timestamp,
+		interval,
+		types.NullOf(types.Uint64(0).Type),
+		list,
+		types.Struct(types.Field("id", types.Uint64(1)), types.Field("at", timestamp)),
+		dict,
+		types.NullOf(list.Type),
+	)
+
+	dest := make([]driver.Value, len(r.cols))
+	require.NoError(t, r.Next(dest))
+	assert.Equal(t, []driver.Value{
+		true,
+		int64(-1),
+		int64(math.MaxInt64),
+		1.5,
+		"text",
+		[]byte("bytes"),
+		ts,
+		int64(time.Second),
+		nil,
+		[]byte(`[1,2]`),
+		[]byte(`{"at":"2024-09-20T12:00:00Z","id":1}`),
+		[]byte(`{"k":"dg=="}`),
+		nil,
+	}, dest)
+	for i, v := range dest {
+		assert.True(t, driver.IsValue(v), "column %d: %T", i, v)
+	}
+
+	r = testRows(types.Uint64(math.MaxInt64 + 1))
+	require.ErrorIs(t, r.Next(make([]driver.Value, 1)), ErrOverflow)
+}
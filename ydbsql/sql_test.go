@@ -0,0 +1,76 @@
+//go:build integration
+
+package ydbsql
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDSN() string {
+	endpoint := "127.0.0.1:2136"
+	if val, ok := os.LookupEnv("YDB_TEST_ENDPOINT"); ok {
+		endpoint = val
+	}
+
+	return "ydb://" + endpoint + "/local"
+}
+
+func TestDriver(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	db, err := sql.Open(DriverName, testDSN())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	require.NoError(t, db.PingContext(ctx))
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE sql_users (id Uint64, name Utf8, PRIMARY KEY (id))`)
+	require.NoError(t, err)
+	defer func() {
+		_, err = db.ExecContext(context.Background(), `DROP TABLE sql_users`)
+		assert.NoError(t, err)
+	}()
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	for i := range 3 {
+		_, err = tx.ExecContext(ctx, `UPSERT INTO sql_users (id, name) VALUES ($p1, $p2)`, uint64(i), "user")
+		require.NoError(t, err)
+	}
+	require.NoError(t, tx.Commit())
+
+	rows, err := db.QueryContext(ctx, `SELECT id, name FROM sql_users WHERE id >= $min ORDER BY id;
+		SELECT COUNT(*) AS cnt FROM sql_users;`, sql.Named("min", uint64(1)))
+	require.NoError(t, err)
+
+	var ids []uint64
+	for rows.Next() {
+		var (
+			id   uint64
+			name sql.NullString
+		)
+		require.NoError(t, rows.Scan(&id, &name))
+		assert.Equal(t, "user", name.String)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []uint64{1, 2}, ids)
+
+	require.True(t, rows.NextResultSet())
+	require.True(t, rows.Next())
+	var cnt uint64
+	require.NoError(t, rows.Scan(&cnt))
+	assert.Equal(t, uint64(3), cnt)
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	_, err = db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	require.ErrorIs(t, err, ErrIsolation)
+}